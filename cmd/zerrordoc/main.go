@@ -0,0 +1,69 @@
+// Command zerrordoc renders the error catalogs as documentation (Markdown table or JSON).
+//
+// The catalogs are loaded with errormessage.RegisterFS, the patterns are relative to the -dir directory:
+//
+//	zerrordoc -format markdown -output docs/errors.md errors/*.yaml
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/znxlc/zerror/errormessage"
+)
+
+func main() {
+	dir := flag.String("dir", ".", "base directory of the catalog patterns")
+	format := flag.String("format", "markdown", "output format: markdown or json")
+	output := flag.String("output", "", "output file (default stdout)")
+	predefined := flag.Bool("predefined", true, "include the predefined errormessage codes")
+	flag.Parse()
+
+	if err := run(*dir, flag.Args(), *format, *output, *predefined); err != nil {
+		fmt.Fprintf(os.Stderr, "zerrordoc: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// run loads the catalogs matching the patterns and writes the documentation
+func run(dir string, patterns []string, format string, output string, predefined bool) error {
+	if len(patterns) == 0 {
+		return fmt.Errorf("at least one catalog pattern is required")
+	}
+	export := errormessage.ExportMarkdown
+	switch format {
+	case "markdown", "md":
+	case "json":
+		export = errormessage.ExportJSON
+	default:
+		return fmt.Errorf("unsupported format %q", format)
+	}
+
+	registry := errormessage.NewRegistry()
+	fsys := os.DirFS(dir)
+	for _, pattern := range patterns {
+		if err := registry.RegisterFS(fsys, pattern); err != nil {
+			return err
+		}
+	}
+	messages := make([]errormessage.Message, 0)
+	for _, message := range registry.Messages() {
+		if predefined || message.Source != "" {
+			messages = append(messages, message)
+		}
+	}
+
+	var w io.Writer = os.Stdout
+	if output != "" {
+		file, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+
+	return export(w, messages)
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "errors"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "errors", "user.yaml"), []byte("- code: ERROR_USER_INVALID\n  msg: User is invalid\n  status: 400\n"), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "errors", "billing.json"), []byte(`[{"code": "ERROR_INVOICE_MISSING", "msg": "Invoice is missing", "severity": "warning"}]`), 0o600))
+
+	output := filepath.Join(dir, "errors.md")
+	assert.NoError(t, run(dir, []string{"errors/*.yaml", "errors/*.json"}, "markdown", output, false))
+	data, err := os.ReadFile(output)
+	assert.NoError(t, err)
+	assert.Equal(t, "| Code | Message | Status | Severity | Source |\n"+
+		"| --- | --- | --- | --- | --- |\n"+
+		"| `ERROR_INVOICE_MISSING` | Invoice is missing |  | warning | errors/billing.json |\n"+
+		"| `ERROR_USER_INVALID` | User is invalid | 400 | error | errors/user.yaml |\n", string(data))
+
+	output = filepath.Join(dir, "errors.json")
+	assert.NoError(t, run(dir, []string{"errors/user.yaml"}, "json", output, true))
+	data, err = os.ReadFile(output)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"code": "ERROR_GENERIC"`)
+	assert.Contains(t, string(data), `"code": "ERROR_USER_INVALID"`)
+
+	assert.EqualError(t, run(dir, nil, "markdown", "", true), "at least one catalog pattern is required")
+	assert.EqualError(t, run(dir, []string{"errors/*.yaml"}, "html", "", true), `unsupported format "html"`)
+	assert.EqualError(t, run(dir, []string{"missing/*.yaml"}, "json", output, true), `no catalog matches the pattern "missing/*.yaml"`)
+}
@@ -0,0 +1,224 @@
+// Command zerrorgen generates Go code from an error catalog (see errormessage.ParseCatalog).
+//
+// The generated file contains a constant for each error code, an init function registering the messages
+// and a constructor for each code taking the Msg placeholders as parameters:
+//
+//	ERROR_USER_LENGTH:
+//	  code: ERROR_USER_LENGTH
+//	  msg: User length {user_length} is below {expected_length}
+//	  params:
+//	    user_length: int
+//
+// generates
+//
+//	const ErrorUserLength = "ERROR_USER_LENGTH"
+//
+//	func NewErrorUserLength(userLength int, expectedLength any) errormessage.IElement
+//
+// Usage with go generate:
+//
+//	//go:generate go run github.com/znxlc/zerror/cmd/zerrorgen -catalog errors.yaml
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/znxlc/zerror/errormessage"
+)
+
+func main() {
+	catalog := flag.String("catalog", "", "catalog file (json or yaml)")
+	pkg := flag.String("package", os.Getenv("GOPACKAGE"), "package name of the generated file (default $GOPACKAGE)")
+	output := flag.String("output", "", "generated file (default <catalog>_zerror.go)")
+	registry := flag.String("registry", "", "optional package variable of type *errormessage.Registry used instead of the default registry")
+	flag.Parse()
+
+	if *catalog == "" || *pkg == "" {
+		fmt.Fprintln(os.Stderr, "zerrorgen: -catalog and -package are required")
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *output == "" {
+		*output = strings.TrimSuffix(*catalog, filepath.Ext(*catalog)) + "_zerror.go"
+	}
+
+	messages, err := errormessage.ParseCatalogFile(*catalog)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "zerrorgen: %s\n", err)
+		os.Exit(1)
+	}
+	source, err := generate(messages, generateOptions{
+		Package:  *pkg,
+		Catalog:  filepath.Base(*catalog),
+		Registry: *registry,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "zerrorgen: %s\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*output, source, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "zerrorgen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// generateOptions contains the settings of the generated file
+type generateOptions struct {
+	Package  string // package name
+	Catalog  string // catalog name, used in the generated header
+	Registry string // registry variable, the default registry is used if empty
+}
+
+// generate returns the formatted Go source for the catalog messages
+func generate(messages []errormessage.Message, options generateOptions) ([]byte, error) {
+	codes := make([]errormessage.Message, 0, len(messages))
+	names := map[string]string{} // constant name by code
+	for _, message := range messages {
+		if message.Locale != "" {
+			continue
+		}
+		name := goName(message.Code, true)
+		for code, existing := range names {
+			if existing == name {
+				return nil, fmt.Errorf("codes %s and %s generate the same name %s", code, message.Code, name)
+			}
+		}
+		names[message.Code] = name
+		codes = append(codes, message)
+	}
+
+	register := "errormessage.RegisterErrors"
+	constructor := "errormessage.New"
+	if options.Registry != "" {
+		register = options.Registry + ".Register"
+		constructor = options.Registry + ".New"
+	}
+
+	buffer := bytes.Buffer{}
+	fmt.Fprintf(&buffer, "// Code generated by zerrorgen from %s; DO NOT EDIT.\n\n", options.Catalog)
+	fmt.Fprintf(&buffer, "package %s\n\n", options.Package)
+	buffer.WriteString("import \"github.com/znxlc/zerror/errormessage\"\n\n")
+
+	buffer.WriteString("// Error codes defined in the catalog\nconst (\n")
+	for _, message := range codes {
+		fmt.Fprintf(&buffer, "%s = %s\n", names[message.Code], strconv.Quote(message.Code))
+	}
+	buffer.WriteString(")\n\n")
+
+	fmt.Fprintf(&buffer, "func init() {\n%s([]errormessage.Message{\n", register)
+	for _, message := range messages {
+		buffer.WriteString(messageLiteral(message, names[message.Code]))
+	}
+	buffer.WriteString("})\n}\n")
+
+	for _, message := range codes {
+		writeConstructor(&buffer, message, names[message.Code], constructor)
+	}
+
+	return format.Source(buffer.Bytes())
+}
+
+// identifierPattern matches the identifiers of a declared param type (int, []string, time.Duration)
+var identifierPattern = regexp.MustCompile(`[\p{L}_][\p{L}\p{Nd}_]*`)
+
+// writeConstructor writes the constructor of the code, the parameters are the placeholders of the message
+func writeConstructor(buffer *bytes.Buffer, message errormessage.Message, name string, constructor string) {
+	placeholders := errormessage.TemplatePlaceholders(message.Msg)
+	params := make([]string, 0, len(placeholders))
+	args := make([]string, 0, len(placeholders))
+	// identifiers used by the constructor, the parameters must not shadow them or each other
+	reserved := map[string]bool{"any": true, "string": true, "errormessage": true, name: true}
+	reserved[strings.Split(constructor, ".")[0]] = true
+	for _, paramType := range message.Params {
+		for _, identifier := range identifierPattern.FindAllString(paramType, -1) {
+			reserved[identifier] = true
+		}
+	}
+	for _, placeholder := range placeholders {
+		param := goName(placeholder, false)
+		if token.IsKeyword(param) || reserved[param] {
+			param += "Value"
+		}
+		for base, suffix := param, 2; reserved[param]; suffix++ { // placeholders converted to the same name (user_name, user.name)
+			param = base + strconv.Itoa(suffix)
+		}
+		reserved[param] = true
+		paramType := message.Params[placeholder]
+		if paramType == "" {
+			paramType = "any"
+		}
+		params = append(params, param+" "+paramType)
+		args = append(args, fmt.Sprintf("%s: %s,\n", strconv.Quote(placeholder), param))
+	}
+
+	fmt.Fprintf(buffer, "\n// New%s returns a new %s element: %s\n", name, message.Code, message.Msg)
+	fmt.Fprintf(buffer, "func New%s(%s) errormessage.IElement {\n", name, strings.Join(params, ", "))
+	if len(args) == 0 {
+		fmt.Fprintf(buffer, "return %s(%s)\n}\n", constructor, name)
+		return
+	}
+	fmt.Fprintf(buffer, "return %s(%s, map[string]any{\n%s})\n}\n", constructor, name, strings.Join(args, ""))
+}
+
+// messageLiteral returns the errormessage.Message composite literal, only the defined fields are written
+func messageLiteral(message errormessage.Message, name string) string {
+	code := strconv.Quote(message.Code)
+	if name != "" {
+		code = name
+	}
+	fields := []string{"Code: " + code, "Msg: " + strconv.Quote(message.Msg)}
+	if message.Locale != "" {
+		fields = append(fields, "Locale: "+strconv.Quote(message.Locale))
+	}
+	if message.Status != 0 {
+		fields = append(fields, "Status: "+strconv.Itoa(message.Status))
+	}
+	if message.Severity != 0 {
+		fields = append(fields, "Severity: errormessage.Severity"+goName(message.Severity.String(), true))
+	}
+	if len(message.Params) > 0 {
+		keys := make([]string, 0, len(message.Params))
+		for key := range message.Params {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		params := make([]string, 0, len(keys))
+		for _, key := range keys {
+			params = append(params, strconv.Quote(key)+": "+strconv.Quote(message.Params[key]))
+		}
+		fields = append(fields, "Params: map[string]string{"+strings.Join(params, ", ")+"}")
+	}
+
+	return "{" + strings.Join(fields, ", ") + "},\n"
+}
+
+// goName converts a code or placeholder name (ERROR_USER_LENGTH, user_length, user.name) to a camel case Go identifier
+func goName(name string, exported bool) string {
+	parts := strings.FieldsFunc(name, func(char rune) bool {
+		return !unicode.IsLetter(char) && !unicode.IsDigit(char)
+	})
+	builder := strings.Builder{}
+	for idx, part := range parts {
+		runes := []rune(strings.ToLower(part))
+		if idx > 0 || exported {
+			runes[0] = unicode.ToUpper(runes[0])
+		}
+		builder.WriteString(string(runes))
+	}
+	identifier := builder.String()
+	if identifier == "" || unicode.IsDigit([]rune(identifier)[0]) {
+		identifier = "E" + identifier
+	}
+	return identifier
+}
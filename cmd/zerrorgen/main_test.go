@@ -0,0 +1,111 @@
+package main
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/znxlc/zerror/errormessage"
+)
+
+func TestGenerate(t *testing.T) {
+	messages, err := errormessage.ParseCatalog(strings.NewReader(`
+- code: ERROR_USER_LENGTH
+  msg: User length {user_length} is below {expected_length}
+  status: 400
+  severity: warning
+  params:
+    user_length: int
+- code: ERROR_USER_MISSING
+  msg: User is missing
+- code: ERROR_USER_MISSING
+  locale: pt
+  msg: Usuário ausente
+`), errormessage.CatalogFormatYAML)
+	assert.NoError(t, err)
+
+	source, err := generate(messages, generateOptions{Package: "users", Catalog: "errors.yaml", Registry: "Catalog"})
+	assert.NoError(t, err)
+	assert.Equal(t, `// Code generated by zerrorgen from errors.yaml; DO NOT EDIT.
+
+package users
+
+import "github.com/znxlc/zerror/errormessage"
+
+// Error codes defined in the catalog
+const (
+	ErrorUserLength  = "ERROR_USER_LENGTH"
+	ErrorUserMissing = "ERROR_USER_MISSING"
+)
+
+func init() {
+	Catalog.Register([]errormessage.Message{
+		{Code: ErrorUserLength, Msg: "User length {user_length} is below {expected_length}", Status: 400, Severity: errormessage.SeverityWarning, Params: map[string]string{"user_length": "int"}},
+		{Code: ErrorUserMissing, Msg: "User is missing"},
+		{Code: ErrorUserMissing, Msg: "Usuário ausente", Locale: "pt"},
+	})
+}
+
+// NewErrorUserLength returns a new ERROR_USER_LENGTH element: User length {user_length} is below {expected_length}
+func NewErrorUserLength(userLength int, expectedLength any) errormessage.IElement {
+	return Catalog.New(ErrorUserLength, map[string]any{
+		"user_length":     userLength,
+		"expected_length": expectedLength,
+	})
+}
+
+// NewErrorUserMissing returns a new ERROR_USER_MISSING element: User is missing
+func NewErrorUserMissing() errormessage.IElement {
+	return Catalog.New(ErrorUserMissing)
+}
+`, string(source))
+	typeCheck(t, source)
+}
+
+func TestGenerate_Names(t *testing.T) {
+	messages := []errormessage.Message{
+		{Code: "ERROR_USER_NAME", Msg: "{user_name} {user.name} {userName}"},
+		{Code: "ERROR_SHADOW", Msg: "{errormessage} {any} {string} {int} {type} {catalog}", Params: map[string]string{"int": "int"}},
+	}
+	source, err := generate(messages, generateOptions{Package: "users", Catalog: "errors.yaml", Registry: "catalog"})
+	assert.NoError(t, err)
+	assert.Contains(t, string(source), "func NewErrorUserName(userName any, userNameValue any, username any)")
+	assert.Contains(t, string(source), "func NewErrorShadow(errormessageValue any, anyValue any, stringValue any, intValue int, typeValue any, catalogValue any)")
+	typeCheck(t, source)
+}
+
+// typeCheck fails the test if the generated source does not compile, Catalog and catalog are declared as registries
+func typeCheck(t *testing.T, source []byte) {
+	t.Helper()
+	registries := `package users
+
+import "github.com/znxlc/zerror/errormessage"
+
+var Catalog, catalog = errormessage.NewRegistry(), errormessage.NewRegistry()
+`
+	fileSet := token.NewFileSet()
+	files := make([]*ast.File, 0, 2)
+	for idx, content := range []string{string(source), registries} {
+		file, err := parser.ParseFile(fileSet, []string{"generated.go", "registries.go"}[idx], content, 0)
+		if !assert.NoError(t, err) {
+			return
+		}
+		files = append(files, file)
+	}
+	config := types.Config{Importer: importer.ForCompiler(fileSet, "source", nil)}
+	_, err := config.Check("users", fileSet, files, nil)
+	assert.NoError(t, err)
+}
+
+func TestGoName(t *testing.T) {
+	assert.Equal(t, "ErrorUserLength", goName("ERROR_USER_LENGTH", true))
+	assert.Equal(t, "userName", goName("user.name", false))
+	assert.Equal(t, "E404NotFound", goName("404_NOT_FOUND", true))
+	assert.Equal(t, "ÉtatInvalide", goName("état_invalide", true))
+	assert.Equal(t, "ăşÉlan", goName("ĂŞ_élan", false))
+}
@@ -0,0 +1,331 @@
+package errormessage
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Catalog formats supported by LoadCatalog, an empty format will detect json or yaml from the content
+const (
+	CatalogFormatJSON = "json"
+	CatalogFormatYAML = "yaml"
+)
+
+// CatalogError is an error found while parsing a catalog, multiple errors are returned joined via errors.Join
+type CatalogError struct {
+	File string // catalog file, empty when loaded from a reader
+	Line int    // line of the entry (0 if unknown)
+	Code string // code of the entry, if any
+	Err  error  // the error found
+}
+
+// Error returns the error in the "file:line: code: error" format ("line N: code: error" without file), omitting the missing parts
+func (ce *CatalogError) Error() string {
+	parts := make([]string, 0, 3)
+	switch {
+	case ce.File != "" && ce.Line > 0:
+		parts = append(parts, fmt.Sprintf("%s:%d", ce.File, ce.Line))
+	case ce.File != "":
+		parts = append(parts, ce.File)
+	case ce.Line > 0:
+		parts = append(parts, fmt.Sprintf("line %d", ce.Line))
+	}
+	if ce.Code != "" {
+		parts = append(parts, ce.Code)
+	}
+	parts = append(parts, ce.Err.Error())
+
+	return strings.Join(parts, ": ")
+}
+
+// Unwrap returns the underlying error
+func (ce *CatalogError) Unwrap() error {
+	return ce.Err
+}
+
+// ParseCatalog reads a catalog without registering it, returning the messages in the catalog order.
+//
+// The catalog can be a map of messages by code, a list of messages or a translation list
+// ({"locale": "pt-BR", "messages": {...}}) in json or yaml format.
+// Entries with an empty code, map keys different from the entry code and duplicate codes are reported as errors,
+// every error found is returned (joined via errors.Join) as *CatalogError.
+func ParseCatalog(r io.Reader, format string) ([]Message, error) {
+	return parseCatalog(r, format, "")
+}
+
+// LoadCatalog parses the catalog (see ParseCatalog) and registers it in the DefaultRegistry
+func LoadCatalog(r io.Reader, format string) error {
+	return DefaultRegistry.LoadCatalog(r, format)
+}
+
+// LoadCatalogFile loads the catalog file (see ParseCatalog) and registers it in the DefaultRegistry,
+// the format is selected from the file extension (.json, .yaml, .yml)
+func LoadCatalogFile(path string) error {
+	return DefaultRegistry.LoadCatalogFile(path)
+}
+
+// LoadCatalog parses the catalog (see ParseCatalog) and registers it, nothing is registered if the catalog contains errors
+func (r *Registry) LoadCatalog(reader io.Reader, format string) error {
+	messages, err := ParseCatalog(reader, format)
+	if err != nil {
+		return err
+	}
+	r.registerMessages(messages)
+
+	return nil
+}
+
+// LoadCatalogFile loads the catalog file (see LoadCatalogFile) and registers it, nothing is registered if the catalog contains errors
+func (r *Registry) LoadCatalogFile(path string) error {
+	messages, err := ParseCatalogFile(path)
+	if err != nil {
+		return err
+	}
+	r.registerMessages(messages)
+
+	return nil
+}
+
+// ParseCatalogFile reads a catalog file without registering it, see ParseCatalog
+func ParseCatalogFile(path string) ([]Message, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return parseCatalog(file, CatalogFormatFromPath(path), path)
+}
+
+// CatalogFormatFromPath returns the catalog format based on the file extension, empty if unknown
+func CatalogFormatFromPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return CatalogFormatJSON
+	case ".yaml", ".yml":
+		return CatalogFormatYAML
+	}
+	return ""
+}
+
+// registerMessages publishes the messages in a single batch
+func (r *Registry) registerMessages(messages []Message) {
+	batch := newRegistryBatch()
+	for _, message := range messages {
+		batch.add(message.Code, message)
+	}
+	r.publish(batch)
+}
+
+// parseCatalog reads and validates the catalog, file is used only for error reporting
+func parseCatalog(r io.Reader, format string, file string) ([]Message, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, &CatalogError{File: file, Err: err}
+	}
+	if format == "" {
+		format = CatalogFormatYAML
+		if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+			format = CatalogFormatJSON
+		}
+	}
+
+	var root *yaml.Node
+	switch format {
+	case CatalogFormatJSON:
+		// validating with the json decoder for accurate syntax errors, the structure is then read token by token for the line numbers
+		var content any
+		if err := json.Unmarshal(data, &content); err != nil {
+			catalogErr := &CatalogError{File: file, Err: err}
+			var syntaxErr *json.SyntaxError
+			if errors.As(err, &syntaxErr) {
+				catalogErr.Line = 1 + bytes.Count(data[:syntaxErr.Offset], []byte("\n"))
+			}
+			return nil, catalogErr
+		}
+		if root, err = parseJSONNode(data); err != nil {
+			return nil, &CatalogError{File: file, Err: err}
+		}
+	case CatalogFormatYAML:
+		document := yaml.Node{}
+		if err := yaml.Unmarshal(data, &document); err != nil {
+			return nil, &CatalogError{File: file, Err: err}
+		}
+		if len(document.Content) == 0 { // empty catalog
+			return []Message{}, nil
+		}
+		root = document.Content[0]
+	default:
+		return nil, &CatalogError{File: file, Err: fmt.Errorf("unsupported catalog format %q", format)}
+	}
+
+	parser := catalogParser{
+		file:  file,
+		lines: map[string]int{},
+	}
+	parser.parseNode(root, "")
+
+	return parser.messages, errors.Join(parser.errors...)
+}
+
+// parseJSONNode converts a json document to a yaml node tree, so both formats are validated by the catalogParser.
+// The json is read with the json decoder (json is not always valid yaml), every node keeps the line of its token.
+func parseJSONNode(data []byte) (*yaml.Node, error) {
+	newlines := make([]int, 0)
+	for offset, char := range data {
+		if char == '\n' {
+			newlines = append(newlines, offset)
+		}
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	return readJSONNode(decoder, newlines)
+}
+
+// readJSONNode reads the next json value from the decoder, newlines contains the offsets of the line breaks
+func readJSONNode(decoder *json.Decoder, newlines []int) (*yaml.Node, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	// the offset of the last byte of the token, json tokens never span multiple lines
+	node := &yaml.Node{Line: 1 + sort.SearchInts(newlines, int(decoder.InputOffset())-1)}
+	switch value := token.(type) {
+	case json.Delim:
+		node.Kind, node.Tag = yaml.SequenceNode, "!!seq"
+		if value == '{' {
+			node.Kind, node.Tag = yaml.MappingNode, "!!map"
+		}
+		for decoder.More() {
+			child, err := readJSONNode(decoder, newlines) // the map keys are read as string nodes
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, child)
+		}
+		if _, err := decoder.Token(); err != nil { // closing delimiter
+			return nil, err
+		}
+	case string:
+		node.Kind, node.Tag, node.Value = yaml.ScalarNode, "!!str", value
+	case json.Number:
+		node.Kind, node.Tag, node.Value = yaml.ScalarNode, "!!int", value.String()
+		if strings.ContainsAny(node.Value, ".eE") {
+			node.Tag = "!!float"
+		}
+	case bool:
+		node.Kind, node.Tag, node.Value = yaml.ScalarNode, "!!bool", strconv.FormatBool(value)
+	case nil:
+		node.Kind, node.Tag, node.Value = yaml.ScalarNode, "!!null", "null"
+	}
+
+	return node, nil
+}
+
+// catalogParser collects the messages and the errors found in a catalog document
+type catalogParser struct {
+	file     string
+	messages []Message
+	errors   []error
+	lines    map[string]int // line of the first definition by code and locale
+}
+
+// parseNode parses the root node of the catalog
+func (cp *catalogParser) parseNode(node *yaml.Node, locale string) {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for _, entry := range node.Content {
+			cp.parseEntry(entry, entry.Line, "", locale)
+		}
+	case yaml.MappingNode:
+		if locale == "" && isLocaleCatalog(node) {
+			cp.parseLocaleCatalog(node)
+			return
+		}
+		for idx := 0; idx+1 < len(node.Content); idx += 2 {
+			cp.parseEntry(node.Content[idx+1], node.Content[idx].Line, node.Content[idx].Value, locale)
+		}
+	default:
+		cp.addError(node.Line, "", errors.New("catalog must be a map or a list of messages"))
+	}
+}
+
+// parseLocaleCatalog parses a translation list in the {"locale": "pt-BR", "messages": {...}} format
+func (cp *catalogParser) parseLocaleCatalog(node *yaml.Node) {
+	locale := ""
+	var messages *yaml.Node
+	for idx := 0; idx+1 < len(node.Content); idx += 2 {
+		switch node.Content[idx].Value {
+		case "locale":
+			locale = node.Content[idx+1].Value
+		case "messages":
+			messages = node.Content[idx+1]
+		}
+	}
+	if locale == "" {
+		cp.addError(node.Line, "", errors.New("translation list without locale"))
+		return
+	}
+	if messages.Kind != yaml.MappingNode && messages.Kind != yaml.SequenceNode {
+		cp.addError(messages.Line, "", errors.New("translation messages must be a map or a list of messages"))
+		return
+	}
+	cp.parseNode(messages, locale)
+}
+
+// parseEntry validates a single catalog entry defined at line, key is the map key (empty for lists)
+func (cp *catalogParser) parseEntry(node *yaml.Node, line int, key string, locale string) {
+	message := Message{}
+	if err := node.Decode(&message); err != nil {
+		cp.addError(line, key, err)
+		return
+	}
+	if locale != "" {
+		message.Locale = locale
+		if message.Code == "" { // translation entries can omit the code, see localeCatalog
+			message.Code = key
+		}
+	}
+	message.Source = cp.file
+	switch {
+	case message.Code == "":
+		cp.addError(line, key, errors.New("empty code"))
+		return
+	case key != "" && key != message.Code:
+		cp.addError(line, key, fmt.Errorf("map key does not match the code %q", message.Code))
+		return
+	}
+
+	id := message.Code + "/" + normalizeLocale(message.Locale)
+	if firstLine, found := cp.lines[id]; found {
+		cp.addError(line, message.Code, fmt.Errorf("duplicate code, first defined at line %d", firstLine))
+		return
+	}
+	cp.lines[id] = line
+	cp.messages = append(cp.messages, message)
+}
+
+// addError records a CatalogError
+func (cp *catalogParser) addError(line int, code string, err error) {
+	cp.errors = append(cp.errors, &CatalogError{File: cp.file, Line: line, Code: code, Err: err})
+}
+
+// isLocaleCatalog returns true if the mapping node is a translation list (has both locale and messages keys)
+func isLocaleCatalog(node *yaml.Node) bool {
+	keys := map[string]bool{}
+	for idx := 0; idx+1 < len(node.Content); idx += 2 {
+		keys[node.Content[idx].Value] = true
+	}
+	return keys["locale"] && keys["messages"]
+}
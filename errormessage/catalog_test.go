@@ -0,0 +1,94 @@
+package errormessage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCatalog(t *testing.T) {
+	messages, err := ParseCatalog(strings.NewReader(`
+ERROR_USER_INVALID:
+  code: ERROR_USER_INVALID
+  msg: User is invalid
+  status: 400
+ERROR_USER_LENGTH:
+  code: ERROR_USER_LENGTH
+  msg: User length {user_length} is below {expected_length}
+`), CatalogFormatYAML)
+	assert.NoError(t, err)
+	assert.Equal(t, []Message{
+		{Code: "ERROR_USER_INVALID", Msg: "User is invalid", Status: 400},
+		{Code: "ERROR_USER_LENGTH", Msg: "User length {user_length} is below {expected_length}"},
+	}, messages)
+
+	messages, err = ParseCatalog(strings.NewReader(`[{"code": "ERROR_A", "msg": "a"}, {"code": "ERROR_B", "msg": "b"}]`), "")
+	assert.NoError(t, err)
+	assert.Len(t, messages, 2)
+
+	// valid json that is not valid yaml
+	messages, err = ParseCatalog(strings.NewReader(`{"ERROR_A": {"code": "ERROR_A", "msg": "a\/b \u00e9", "status": 400, "severity": "warning"}}`), "")
+	assert.NoError(t, err)
+	assert.Equal(t, []Message{{Code: "ERROR_A", Msg: "a/b é", Status: 400, Severity: SeverityWarning}}, messages)
+
+	// translation entries take the code from the map key
+	messages, err = ParseCatalog(strings.NewReader("locale: pt\nmessages:\n  ERROR_INTERNAL:\n    msg: Ocorreu um erro interno\n"), "")
+	assert.NoError(t, err)
+	assert.Equal(t, []Message{{Code: "ERROR_INTERNAL", Msg: "Ocorreu um erro interno", Locale: "pt"}}, messages)
+}
+
+func TestParseCatalog_Errors(t *testing.T) {
+	_, err := ParseCatalog(strings.NewReader(`
+ERROR_A:
+  code: ERROR_A
+ERROR_B:
+  code: ERROR_OTHER
+ERROR_C:
+  msg: no code
+ERROR_A:
+  code: ERROR_A
+ERROR_A:
+  code: ERROR_A
+`), CatalogFormatYAML)
+	assert.EqualError(t, err, strings.Join([]string{
+		`line 4: ERROR_B: map key does not match the code "ERROR_OTHER"`,
+		`line 6: ERROR_C: empty code`,
+		`line 8: ERROR_A: duplicate code, first defined at line 2`,
+		`line 10: ERROR_A: duplicate code, first defined at line 2`,
+	}, "\n"))
+
+	_, err = ParseCatalog(strings.NewReader("{\n  \"ERROR_A\": {\"code\": \"ERROR_A\"},\n  \"ERROR_B\": {\"code\" \"ERROR_B\"}\n}"), CatalogFormatJSON)
+	var catalogErr *CatalogError
+	assert.True(t, errors.As(err, &catalogErr))
+	assert.Equal(t, 3, catalogErr.Line)
+
+	_, err = ParseCatalog(strings.NewReader("[\n  {\"code\": \"ERROR_A\"},\n  {\"msg\": \"no code\"},\n  {\n    \"code\": \"ERROR_A\"\n  }\n]"), CatalogFormatJSON)
+	assert.EqualError(t, err, strings.Join([]string{
+		`line 3: empty code`,
+		`line 4: ERROR_A: duplicate code, first defined at line 2`,
+	}, "\n"))
+
+	_, err = ParseCatalog(strings.NewReader("ERROR_A:\n  code: [\n"), CatalogFormatYAML)
+	assert.ErrorContains(t, err, "yaml: line 2")
+}
+
+func TestLoadCatalogFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"ERROR_A": {"code": "ERROR_A"}, "ERROR_B": {"code": ""}}`), 0o600))
+	registry := NewRegistry()
+	err := registry.LoadCatalogFile(path)
+	assert.EqualError(t, err, path+":1: ERROR_B: empty code")
+	// nothing is registered if the catalog contains errors
+	_, found := registry.Lookup("ERROR_A")
+	assert.False(t, found)
+
+	assert.NoError(t, os.WriteFile(path, []byte(`{"ERROR_A": {"code": "ERROR_A", "msg": "error a"}}`), 0o600))
+	assert.NoError(t, registry.LoadCatalogFile(path))
+	message, found := registry.Lookup("ERROR_A")
+	assert.True(t, found)
+	assert.Equal(t, "error a", message.Msg)
+}
@@ -0,0 +1,96 @@
+package errormessage
+
+import (
+	"path"
+	"strings"
+)
+
+// Code is an error code that can be used as a target for errors.Is, matching any element with the same code
+//
+//	errors.Is(err, errormessage.Code(errormessage.ErrorInternal))
+type Code string
+
+// Error returns the code as string
+func (c Code) Error() string {
+	return string(c)
+}
+
+// ParsedCode is an error code split in segments following the ENTITY_<ATTRIBUTE/VERB>_LIST format
+//
+//	ParseCode("ERROR_DB_CONNECTION_FAILED") // Prefix: ERROR, Entity: DB, Attribute: CONNECTION, Verb: FAILED
+type ParsedCode struct {
+	Code      string   // the full code
+	Segments  []string // the code segments separated by '_'
+	Prefix    string   // the ERROR prefix, empty if the code does not start with it
+	Entity    string   // the first segment after the prefix
+	Attribute string   // the segments between entity and verb, empty if missing
+	Verb      string   // the last segment, empty if the code has only the entity
+}
+
+// ParseCode splits the code in segments
+func ParseCode(code string) ParsedCode {
+	parsed := ParsedCode{
+		Code:     code,
+		Segments: strings.Split(code, "_"),
+	}
+	segments := parsed.Segments
+	if len(segments) > 1 && segments[0] == "ERROR" {
+		parsed.Prefix = segments[0]
+		segments = segments[1:]
+	}
+	parsed.Entity = segments[0]
+	if len(segments) > 1 {
+		parsed.Verb = segments[len(segments)-1]
+		parsed.Attribute = strings.Join(segments[1:len(segments)-1], "_")
+	}
+
+	return parsed
+}
+
+// Parse splits the code in segments, see ParseCode
+func (c Code) Parse() ParsedCode {
+	return ParseCode(string(c))
+}
+
+// Match reports whether the code matches the pattern, see MatchCode
+func (c Code) Match(pattern string) bool {
+	return MatchCode(pattern, string(c))
+}
+
+// MatchCode reports whether the code matches the pattern, the pattern is compared segment by segment ('_' separated):
+//
+//	"*"      matches one or more segments (ERROR_USER_* matches ERROR_USER_LENGTH and ERROR_USER_NAME_INVALID)
+//	USER*    segments containing wildcards are matched with path.Match (USER* matches USER and USERS)
+//	USER     any other segment must be equal
+//
+// Use HasCodePrefix to include the family code itself (ERROR_USER).
+func MatchCode(pattern string, code string) bool {
+	return matchSegments(strings.Split(pattern, "_"), strings.Split(code, "_"))
+}
+
+// HasCodePrefix reports whether the code belongs to the family, i.e. it is the family code or it starts with family followed by '_'
+func HasCodePrefix(code string, family string) bool {
+	return code == family || strings.HasPrefix(code, family+"_")
+}
+
+// matchSegments matches the code segments against the pattern segments
+func matchSegments(pattern []string, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+	if pattern[0] == "*" {
+		for consumed := 1; consumed <= len(segments); consumed++ {
+			if matchSegments(pattern[1:], segments[consumed:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	if matched, err := path.Match(pattern[0], segments[0]); err != nil || !matched {
+		return false
+	}
+	return matchSegments(pattern[1:], segments[1:])
+}
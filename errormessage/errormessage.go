@@ -2,30 +2,70 @@ package errormessage
 
 import (
 	"encoding/json"
+	"errors"
 )
 
 // Message is the bare error message struct
 type Message struct {
-	Code string `json:"code"` // error code
-	Msg  string `json:"msg"`  // error message
+	Code     string            `json:"code"`                      // error code
+	Msg      string            `json:"msg"`                       // error message, can contain {name} placeholders rendered from the element Args
+	Locale   string            `json:"locale,omitempty"`          // the message is a translation of Code in this locale (default message if empty)
+	Status   int               `json:"status,omitempty"`          // HTTP status code associated with the error
+	Severity Severity          `json:"severity,omitempty"`        // severity of the error (SeverityError if not defined)
+	Source   string            `json:"source,omitempty" yaml:"-"` // catalog file the message was loaded from, set by the catalog loaders
+	Params   map[string]string `json:"params,omitempty"`          // optional Go types of the Msg placeholders, used by zerrorgen (any if not declared)
 }
 
 // tElement represents a single error element
 type tElement struct {
-	Args map[string]any `json:"args"` // error optional args
-	Code string         `json:"code"` // error code
-	Msg  string         `json:"msg"`  // error message
-	//Trace []TraceElement `json:"-"`    // TODO trace list
+	Args     map[string]any `json:"args"` // error optional args
+	Code     string         `json:"code"` // error code
+	Msg      string         `json:"msg"`  // error message, can be a template rendered with Args (see RenderMessage)
+	Trace    []TraceElement `json:"-"`    // call stack recorded when the element was created
+	Cause    error          `json:"-"`    // the original error the element was created from
+	Status   int            `json:"-"`    // HTTP status code loaded from the registered message
+	Severity Severity       `json:"-"`    // severity loaded from the registered message or set via Set()
+	Field    Field          `json:"-"`    // path of the field the error refers to, in dotted notation
+	Origin   Origin         `json:"-"`    // layers the element was merged through, outermost first
+	Children []IElement     `json:"-"`    // child elements, e.g. the field errors of a validation error
+
+	registry *Registry // the registry used to load the messages (DefaultRegistry if nil)
+}
+
+// jsonElement is the JSON representation of the tElement
+type jsonElement struct {
+	Args     map[string]any    `json:"args"`
+	Code     string            `json:"code"`
+	Msg      string            `json:"msg"`
+	Rendered string            `json:"rendered,omitempty"`
+	Cause    string            `json:"cause,omitempty"`
+	Status   int               `json:"status,omitempty"`
+	Severity Severity          `json:"severity,omitempty"`
+	Field    Field             `json:"field,omitempty"`
+	Origin   Origin            `json:"origin,omitempty"`
+	Trace    []TraceElement    `json:"trace,omitempty"`
+	Children []json.RawMessage `json:"children,omitempty"`
 }
 
 // IElement represents the interface for the tElement
 type IElement interface {
+	AddChild(...IElement)
 	Error() string
 	Get() IElement
 	GetCode() string
+	GetField() Field
 	GetMsg() string
+	GetMsgTemplate() string
+	GetOrigin() Origin
+	GetRegistry() *Registry
 	GetArgs() map[string]any
+	GetChildren() []IElement
+	GetSeverity() Severity
+	GetStatus() int
+	GetTrace() []TraceElement
+	Unwrap() error
 	Load(string) bool
+	Localize(string) IElement
 	Set(args ...any) bool
 	MarshalJSON() ([]byte, error)
 	UnmarshalJSON([]byte) error
@@ -34,19 +74,14 @@ type IElement interface {
 // ErrorElementGenerator is an alias for the constructor function New
 type ErrorElementGenerator = func(args ...any) IElement
 
-// New will generate a new tElement starting from ErrorGeneric
+// New will generate a new tElement from the DefaultRegistry starting from ErrorGeneric, recording the caller's stack trace (see TraceDepth and TraceSkip)
 func New(args ...any) IElement {
-	errElement := new(tElement)
-	// setting default value
-	errElement.Load(ErrorGeneric)
-	errElement.Set(args...)
-
-	return errElement
+	return DefaultRegistry.New(args...)
 }
 
-// Error returns an error from current element
+// Error returns an error from current element, the message rendered with the element Args
 func (ee *tElement) Error() string {
-	return ee.Msg
+	return ee.GetMsg()
 }
 
 // Get returns the tElement packed in the interface
@@ -71,15 +106,25 @@ func (ee *tElement) Get() IElement {
 //		  errormessage.IElement
 //		    a prefilled IElement we wish to edit
 //		  error
-//			the errElement.Msg will be set to errorItem.Error()
+//			the errElement.Msg will be set to errorItem.Error() and the error is kept as the element Cause
 //		args
 //		  will represent the rest of the params needed to create a new IElement (based on type)
 //		  string
 //		     will set the IElement.Msg field to the specified value
+//		  error
+//		     will set the IElement.Cause, the Msg remains unchanged
 //		  map[string]any
 //		     will add the keys to IElement.Args
+//		  Severity
+//		     will set the IElement severity
+//		  Field
+//		     will set the IElement field path (stored in dotted notation)
+//		  Origin
+//		     will prepend the origin to the IElement origin path (origin/current)
 //		  TraceElement, []TraceElement
 //		     will append the TraceElement to IElement.Trace
+//		  []IElement, GetList() []IElement (e.g. zerror.Error)
+//		     will append the elements to the IElement children
 //		  other
 //		     will be ignored
 //
@@ -104,16 +149,30 @@ func (ee *tElement) Set(args ...any) bool {
 				switch eItem := errorItem.(type) {
 				case string:
 					ee.Code = eItem
-					ee.Load(eItem) // load entire IElement if found in registered list, element will remain unchanged if not found
+					// load entire IElement if found in registered list, the ErrorGeneric status and severity do not apply to unregistered codes
+					if !ee.Load(eItem) {
+						ee.Status = 0
+						ee.Severity = 0
+					}
 				case Message:
 					ee.Code = eItem.Code
 					ee.Msg = eItem.Msg
 				case IElement:
 					ee.Code = eItem.GetCode()
-					ee.Msg = eItem.GetMsg()
+					ee.Msg = eItem.GetMsgTemplate()
 					ee.Args = eItem.GetArgs()
+					ee.Cause = eItem.Unwrap()
+					ee.Status = eItem.GetStatus()
+					ee.Severity = eItem.GetSeverity()
+					ee.Field = eItem.GetField()
+					ee.Origin = eItem.GetOrigin()
+					ee.Children = append([]IElement(nil), eItem.GetChildren()...)
+					if trace := eItem.GetTrace(); len(trace) > 0 {
+						ee.Trace = trace
+					}
 				case error:
 					ee.Msg = eItem.Error()
+					ee.Cause = eItem
 				default: // parameter not supported, the error message will contain the actual error
 					ee.Load(ErrorGenerateParameterInvalid)
 					ee.Args = map[string]any{
@@ -127,12 +186,26 @@ func (ee *tElement) Set(args ...any) bool {
 			}
 
 			switch element := arg.(type) {
+			case []IElement:
+				ee.Children = append(ee.Children, element...)
+			case interface{ GetList() []IElement }: // checked before error, a zerror becomes a list of children
+				ee.Children = append(ee.Children, element.GetList()...)
 			case string: // overwriting the Msg
 				ee.Msg = element
-			case error:
-				ee.Msg = element.Error()
+			case error: // keeping the original error, a registered Msg is not overwritten
+				ee.Cause = element
 			case map[string]any: // add the arguments
 				ee.Args = element
+			case Severity:
+				ee.Severity = element
+			case Field:
+				ee.Field = Field(element.Dotted())
+			case Origin:
+				ee.Origin = element.Join(ee.Origin)
+			case TraceElement:
+				ee.Trace = append(ee.Trace, element)
+			case []TraceElement:
+				ee.Trace = append(ee.Trace, element...)
 			}
 		}
 	}
@@ -145,8 +218,23 @@ func (ee *tElement) GetCode() string {
 	return ee.Code
 }
 
-// GetMsg returns the errorMessage.Msg
+// GetOrigin returns the origin path of the element, empty if the element was not merged with an Origin
+func (ee *tElement) GetOrigin() Origin {
+	return ee.Origin
+}
+
+// GetField returns the path of the field the error refers to in dotted notation, empty if not defined
+func (ee *tElement) GetField() Field {
+	return ee.Field
+}
+
+// GetMsg returns the errorMessage.Msg with the placeholders rendered from the errorMessage.Args
 func (ee *tElement) GetMsg() string {
+	return RenderMessage(ee.Msg, ee.Args)
+}
+
+// GetMsgTemplate returns the raw errorMessage.Msg, without rendering the placeholders
+func (ee *tElement) GetMsgTemplate() string {
 	return ee.Msg
 }
 
@@ -155,19 +243,115 @@ func (ee *tElement) GetArgs() map[string]any {
 	return ee.Args
 }
 
+// GetSeverity returns the severity of the element, SeverityError if not defined
+func (ee *tElement) GetSeverity() Severity {
+	if ee.Severity == 0 {
+		return SeverityError
+	}
+	return ee.Severity
+}
+
+// GetStatus returns the HTTP status code of the element (0 if not defined)
+func (ee *tElement) GetStatus() int {
+	return ee.Status
+}
+
+// GetTrace returns the call stack recorded when the element was created
+func (ee *tElement) GetTrace() []TraceElement {
+	return ee.Trace
+}
+
+// Unwrap returns the original error the element was created from (nil if none), used by errors.Is and errors.As
+func (ee *tElement) Unwrap() error {
+	return ee.Cause
+}
+
+// Is reports whether the element matches the target, used by errors.Is.
+// The target matches if it is a Code or an IElement with the same error code as the element or one of its descendants.
+func (ee *tElement) Is(target error) bool {
+	switch t := target.(type) {
+	case Code:
+		if ee.Code == string(t) {
+			return true
+		}
+	case IElement:
+		if ee.Code == t.GetCode() {
+			return true
+		}
+	}
+	for _, child := range ee.Children {
+		if errors.Is(child, target) {
+			return true
+		}
+	}
+	return false
+}
+
 // Load will attempt to create a copy of a registered error and populate the object with its fields
 func (ee *tElement) Load(code string) bool {
-	errElement, found := registeredErrorsMap[code]
+	errElement, found := ee.GetRegistry().Lookup(code)
 	if found {
 		ee.Code = errElement.Code
 		ee.Msg = errElement.Msg
+		ee.Status = errElement.Status
+		ee.Severity = errElement.Severity
 	}
 	return found
 }
 
-// UnmarshalJSON is a function to make IElement compatible with json.Marshal.
+// Localize returns a copy of the element with the message translated in the requested locale (see Registry.Translate).
+// Custom messages, different from the registered one, are not translated.
+func (ee *tElement) Localize(locale string) IElement {
+	localized := *ee
+	registry := ee.GetRegistry()
+	if message, found := registry.Lookup(ee.Code); found && message.Msg == ee.Msg {
+		localized.Msg, _ = registry.Translate(ee.Code, locale)
+	}
+	localized.Children = make([]IElement, 0, len(ee.Children))
+	for _, child := range ee.Children {
+		localized.Children = append(localized.Children, child.Localize(locale))
+	}
+	return &localized
+}
+
+// GetRegistry returns the registry the element is bound to, the DefaultRegistry if the element is not bound
+func (ee *tElement) GetRegistry() *Registry {
+	if ee.registry == nil {
+		return DefaultRegistry
+	}
+	return ee.registry
+}
+
+// UnmarshalJSON is a function to make IElement compatible with json.Unmarshal.
+//
+// All the fields are replaced with the decoded ones, the cause is restored as a plain error containing the cause message.
 func (ee *tElement) UnmarshalJSON(data []byte) error {
-	return json.Unmarshal(data, ee)
+	element := jsonElement{}
+	if err := json.Unmarshal(data, &element); err != nil {
+		return err
+	}
+	ee.Args = element.Args
+	ee.Code = element.Code
+	ee.Msg = element.Msg
+	ee.Status = element.Status
+	ee.Severity = element.Severity
+	ee.Field = element.Field
+	ee.Origin = element.Origin
+	ee.Children = nil
+	for _, data := range element.Children {
+		child := &tElement{registry: ee.registry}
+		if err := child.UnmarshalJSON(data); err != nil {
+			return err
+		}
+		ee.Children = append(ee.Children, child)
+	}
+	ee.Trace = element.Trace
+	ee.Cause = nil
+	if element.Cause != "" {
+		ee.Cause = errors.New(element.Cause)
+	}
+
+	return nil
 }
 
 // MarshalJSON is a function to make IElement compatible with json.Marshal.
@@ -179,9 +363,35 @@ func (ee *tElement) UnmarshalJSON(data []byte) error {
 // Outputs:
 //
 //	[]byte
-//	  The JSON representation of the IElement struct
+//	  The JSON representation of the IElement struct, the trace is included only if TraceMarshal is enabled.
+//	  msg holds the raw template, rendered holds the message rendered with Args when it differs from the template
 //	error
 //	  Marshal error, if any occurred
 func (ee *tElement) MarshalJSON() ([]byte, error) {
-	return json.Marshal(ee)
+	element := jsonElement{
+		Args:     ee.Args,
+		Code:     ee.Code,
+		Msg:      ee.Msg,
+		Status:   ee.Status,
+		Severity: ee.Severity,
+		Field:    ee.Field,
+		Origin:   ee.Origin,
+	}
+	if rendered := ee.GetMsg(); rendered != ee.Msg {
+		element.Rendered = rendered
+	}
+	if ee.Cause != nil {
+		element.Cause = ee.Cause.Error()
+	}
+	if TraceMarshal {
+		element.Trace = ee.Trace
+	}
+	for _, child := range ee.Children {
+		data, err := child.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		element.Children = append(element.Children, data)
+	}
+	return json.Marshal(element)
 }
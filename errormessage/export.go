@@ -0,0 +1,69 @@
+package errormessage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Messages returns the default messages registered (translations excluded), sorted by code
+func (r *Registry) Messages() []Message {
+	snapshot := r.current.Load()
+	messages := make([]Message, 0, len(snapshot.messages))
+	for _, message := range snapshot.messages {
+		messages = append(messages, message)
+	}
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].Code < messages[j].Code
+	})
+
+	return messages
+}
+
+// ExportJSON writes the messages as an indented JSON document in the {"errors": [...]} format
+func ExportJSON(w io.Writer, messages []Message) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(struct {
+		Errors []Message `json:"errors"`
+	}{Errors: messages})
+}
+
+// ExportMarkdown writes the messages as a Markdown table with code, message, HTTP status, severity and source file,
+// messages without a severity are listed as error (see IElement.GetSeverity)
+func ExportMarkdown(w io.Writer, messages []Message) error {
+	builder := strings.Builder{}
+	builder.WriteString("| Code | Message | Status | Severity | Source |\n")
+	builder.WriteString("| --- | --- | --- | --- | --- |\n")
+	for _, message := range messages {
+		status := ""
+		if message.Status != 0 {
+			status = strconv.Itoa(message.Status)
+		}
+		severity := message.Severity
+		if severity == 0 {
+			severity = SeverityError
+		}
+		fmt.Fprintf(&builder, "| `%s` | %s | %s | %s | %s |\n",
+			message.Code,
+			markdownCell(message.Msg),
+			status,
+			severity,
+			markdownCell(message.Source),
+		)
+	}
+	_, err := io.WriteString(w, builder.String())
+
+	return err
+}
+
+// markdownCell escapes the text so it can be used in a table cell
+func markdownCell(text string) string {
+	text = strings.ReplaceAll(text, "|", `\|`)
+	text = strings.ReplaceAll(text, "\r\n", "<br>")
+	return strings.ReplaceAll(text, "\n", "<br>")
+}
@@ -0,0 +1,30 @@
+package errormessage
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportMarkdown(t *testing.T) {
+	registry := NewRegistry(
+		Message{Code: "ERROR_USER_INVALID", Msg: "User is invalid | empty", Status: 400, Severity: SeverityWarning, Source: "errors/user.yaml"},
+		Message{Code: "ERROR_USER_INVALID", Msg: "Usuário inválido", Locale: "pt"},
+	)
+	buffer := bytes.Buffer{}
+	assert.NoError(t, ExportMarkdown(&buffer, registry.Messages()))
+	assert.Equal(t, "| Code | Message | Status | Severity | Source |\n"+
+		"| --- | --- | --- | --- | --- |\n"+
+		"| `ERROR_GENERATE_PARAMETER_INVALID` | Unable to generate error element, parameter invalid | 500 | error |  |\n"+
+		"| `ERROR_GENERIC` | An error has occurred | 500 | error |  |\n"+
+		"| `ERROR_INTERNAL` | An internal error has occurred | 500 | error |  |\n"+
+		"| `ERROR_PANIC` | A fatal error has occurred | 500 | critical |  |\n"+
+		"| `ERROR_USER_INVALID` | User is invalid \\| empty | 400 | warning | errors/user.yaml |\n", buffer.String())
+}
+
+func TestExportJSON(t *testing.T) {
+	buffer := bytes.Buffer{}
+	assert.NoError(t, ExportJSON(&buffer, []Message{{Code: "ERROR_USER_INVALID", Msg: "User is invalid", Status: 400, Severity: SeverityWarning, Source: "errors/user.yaml"}}))
+	assert.JSONEq(t, `{"errors": [{"code": "ERROR_USER_INVALID", "msg": "User is invalid", "status": 400, "severity": "warning", "source": "errors/user.yaml"}]}`, buffer.String())
+}
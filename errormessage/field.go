@@ -0,0 +1,35 @@
+package errormessage
+
+import (
+	"strings"
+)
+
+// Field is the path of the field an element refers to, in dotted (user.email) or JSON Pointer (/user/email) notation.
+// Elements store the dotted notation so paths written in either notation can be compared.
+type Field string
+
+// Dotted returns the field path in dotted notation (user.addresses.0.street)
+func (f Field) Dotted() string {
+	path := string(f)
+	if !strings.HasPrefix(path, "/") {
+		return path
+	}
+	segments := strings.Split(path[1:], "/")
+	for idx, segment := range segments {
+		segments[idx] = strings.ReplaceAll(strings.ReplaceAll(segment, "~1", "/"), "~0", "~")
+	}
+	return strings.Join(segments, ".")
+}
+
+// Pointer returns the field path as JSON Pointer (/user/addresses/0/street), see RFC 6901
+func (f Field) Pointer() string {
+	path := string(f)
+	if path == "" || strings.HasPrefix(path, "/") {
+		return path
+	}
+	segments := strings.Split(path, ".")
+	for idx, segment := range segments {
+		segments[idx] = strings.ReplaceAll(strings.ReplaceAll(segment, "~", "~0"), "/", "~1")
+	}
+	return "/" + strings.Join(segments, "/")
+}
@@ -0,0 +1,75 @@
+package errormessage
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Format implements fmt.Formatter.
+//
+//	%s, %v  the rendered message (same as Error())
+//	%q      the quoted rendered message
+//	%+v     multi-line listing with code, msg, sorted args, cause, trace and the indented children
+func (ee *tElement) Format(state fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if state.Flag('+') {
+			io.WriteString(state, FormatVerbose(ee, ""))
+			return
+		}
+		io.WriteString(state, ee.Error())
+	case 's':
+		io.WriteString(state, ee.Error())
+	case 'q':
+		fmt.Fprintf(state, "%q", ee.Error())
+	default:
+		fmt.Fprintf(state, "%%!%c(%s)", verb, ee.Error())
+	}
+}
+
+// FormatVerbose returns the multi-line representation of the element used by %+v, each line is prefixed with indent
+func FormatVerbose(element IElement, indent string) string {
+	builder := strings.Builder{}
+	fmt.Fprintf(&builder, "%scode: %s\n", indent, element.GetCode())
+	fmt.Fprintf(&builder, "%smsg:  %s\n", indent, element.GetMsg())
+	if field := element.GetField(); field != "" {
+		fmt.Fprintf(&builder, "%sfield: %s\n", indent, field)
+	}
+	if origin := element.GetOrigin(); origin != "" {
+		fmt.Fprintf(&builder, "%sorigin: %s\n", indent, origin)
+	}
+	if severity := element.GetSeverity(); severity != SeverityError {
+		fmt.Fprintf(&builder, "%sseverity: %s\n", indent, severity)
+	}
+	if args := element.GetArgs(); len(args) > 0 {
+		keys := make([]string, 0, len(args))
+		for key := range args {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		fmt.Fprintf(&builder, "%sargs:\n", indent)
+		for _, key := range keys {
+			fmt.Fprintf(&builder, "%s  %s: %v\n", indent, key, args[key])
+		}
+	}
+	if cause := element.Unwrap(); cause != nil {
+		fmt.Fprintf(&builder, "%scause: %s\n", indent, cause.Error())
+	}
+	if trace := element.GetTrace(); len(trace) > 0 {
+		fmt.Fprintf(&builder, "%strace:\n", indent)
+		for _, frame := range trace {
+			fmt.Fprintf(&builder, "%s  %s\n%s    %s:%d\n", indent, frame.Function, indent, frame.File, frame.Line)
+		}
+	}
+	if children := element.GetChildren(); len(children) > 0 {
+		fmt.Fprintf(&builder, "%schildren:\n", indent)
+		for idx, child := range children {
+			fmt.Fprintf(&builder, "%s  [%d]\n", indent, idx)
+			builder.WriteString(FormatVerbose(child, indent+"    "))
+		}
+	}
+
+	return builder.String()
+}
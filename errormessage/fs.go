@@ -0,0 +1,77 @@
+package errormessage
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+)
+
+// RegisterFS loads every catalog matching the glob pattern from fsys and registers it in the DefaultRegistry, see Registry.RegisterFS()
+//
+//	//go:embed errors/*.yaml
+//	var catalogs embed.FS
+//
+//	err := errormessage.RegisterFS(catalogs, "errors/*.yaml")
+func RegisterFS(fsys fs.FS, pattern string) error {
+	return DefaultRegistry.RegisterFS(fsys, pattern)
+}
+
+// RegisterFS loads every catalog matching the glob pattern from fsys (see fs.Glob) and registers it.
+//
+// The files are loaded in lexical order and the format is selected from the file extension (see CatalogFormatFromPath).
+// Each message records its file in Message.Source, a code defined in two files (or already registered from a different file)
+// is reported as a conflict with both file names.
+// Nothing is registered if any of the files contains errors, all the errors found are returned joined via errors.Join.
+func (r *Registry) RegisterFS(fsys fs.FS, pattern string) error {
+	files, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no catalog matches the pattern %q", pattern)
+	}
+	sort.Strings(files)
+
+	current := r.current.Load()
+	sources := map[string]string{} // source file by code and locale for the loaded messages
+	messages := make([]Message, 0)
+	errList := make([]error, 0)
+	for _, file := range files {
+		fileMessages, err := parseCatalogFS(fsys, file)
+		if err != nil {
+			errList = append(errList, err)
+			continue
+		}
+		for _, message := range fileMessages {
+			id := message.Code + "/" + normalizeLocale(message.Locale)
+			if source, found := sources[id]; found {
+				errList = append(errList, &CatalogError{File: file, Code: message.Code, Err: fmt.Errorf("conflicts with the code defined in %s", source)})
+				continue
+			}
+			if registered, found := current.messages[message.Code]; found && message.Locale == "" && registered.Source != "" && registered.Source != file {
+				errList = append(errList, &CatalogError{File: file, Code: message.Code, Err: fmt.Errorf("conflicts with the code registered from %s", registered.Source)})
+				continue
+			}
+			sources[id] = file
+			messages = append(messages, message)
+		}
+	}
+	if len(errList) > 0 {
+		return errors.Join(errList...)
+	}
+	r.registerMessages(messages)
+
+	return nil
+}
+
+// parseCatalogFS parses a single catalog file from fsys
+func parseCatalogFS(fsys fs.FS, file string) ([]Message, error) {
+	reader, err := fsys.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return parseCatalog(reader, CatalogFormatFromPath(file), file)
+}
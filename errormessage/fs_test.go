@@ -0,0 +1,53 @@
+package errormessage
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"errors/user.yaml":    {Data: []byte("ERROR_USER_INVALID:\n  code: ERROR_USER_INVALID\n  msg: User is invalid\n")},
+		"errors/billing.json": {Data: []byte(`[{"code": "ERROR_INVOICE_MISSING", "msg": "Invoice is missing"}]`)},
+		"errors/pt.yaml":      {Data: []byte("locale: pt\nmessages:\n  ERROR_USER_INVALID:\n    code: ERROR_USER_INVALID\n    msg: Usuário inválido\n")},
+		"errors/de.json":      {Data: []byte(`{"locale": "de", "messages": {"ERROR_INVOICE_MISSING": {"msg": "Rechnung fehlt"}}}`)},
+		"errors/readme.txt":   {Data: []byte("not a catalog")},
+	}
+	registry := NewRegistry()
+	assert.NoError(t, registry.RegisterFS(fsys, "errors/*.yaml"))
+	assert.NoError(t, registry.RegisterFS(fsys, "errors/*.json"))
+
+	message, found := registry.Lookup("ERROR_USER_INVALID")
+	assert.True(t, found)
+	assert.Equal(t, "errors/user.yaml", message.Source)
+	message, _ = registry.Lookup("ERROR_INVOICE_MISSING")
+	assert.Equal(t, "errors/billing.json", message.Source)
+	msg, _ := registry.Translate("ERROR_USER_INVALID", "pt-BR")
+	assert.Equal(t, "Usuário inválido", msg)
+	msg, _ = registry.Translate("ERROR_INVOICE_MISSING", "de")
+	assert.Equal(t, "Rechnung fehlt", msg)
+
+	// loading the same files again is not a conflict
+	assert.NoError(t, registry.RegisterFS(fsys, "errors/*.yaml"))
+	assert.EqualError(t, registry.RegisterFS(fsys, "missing/*.yaml"), `no catalog matches the pattern "missing/*.yaml"`)
+}
+
+func TestRegisterFS_Conflicts(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a/errors.yaml": {Data: []byte("- code: ERROR_DUPLICATE\n  msg: first\n- code: ERROR_A\n")},
+		"b/errors.yaml": {Data: []byte("- code: ERROR_DUPLICATE\n  msg: second\n- code: ERROR_B\n")},
+		"c/errors.yaml": {Data: []byte("- code: ERROR_A\n")},
+	}
+	registry := NewRegistry()
+	err := registry.RegisterFS(fsys, "[ab]/errors.yaml")
+	assert.EqualError(t, err, "b/errors.yaml: ERROR_DUPLICATE: conflicts with the code defined in a/errors.yaml")
+	_, found := registry.Lookup("ERROR_B")
+	assert.False(t, found)
+
+	assert.NoError(t, registry.RegisterFS(fsys, "a/errors.yaml"))
+	err = registry.RegisterFS(fsys, "c/*.yaml")
+	assert.True(t, strings.Contains(err.Error(), "conflicts with the code registered from a/errors.yaml"))
+}
@@ -0,0 +1,53 @@
+package errormessage
+
+import (
+	"strings"
+)
+
+// localeCatalog is the format used to register the translations of a single locale
+//
+//	locale: pt-BR
+//	messages:
+//	  ERROR_INTERNAL:
+//	    msg: Ocorreu um erro interno
+type localeCatalog struct {
+	Locale   string             `json:"locale" yaml:"locale"`
+	Messages map[string]Message `json:"messages" yaml:"messages"`
+}
+
+// register adds the translations to the batch, returns false if the catalog does not contain translations
+func (lc localeCatalog) register(batch *registryBatch) bool {
+	if lc.Locale == "" || len(lc.Messages) == 0 {
+		return false
+	}
+	for code, message := range lc.Messages {
+		if message.Code == "" {
+			message.Code = code
+		}
+		message.Locale = lc.Locale
+		batch.add(code, message)
+	}
+	return true
+}
+
+// LocaleFallback returns the normalized locales to be searched for a translation, from the most specific to the least specific
+//
+//	LocaleFallback("pt_BR") // []string{"pt-br", "pt"}
+func LocaleFallback(locale string) []string {
+	locale = normalizeLocale(locale)
+	fallback := make([]string, 0)
+	for locale != "" {
+		fallback = append(fallback, locale)
+		idx := strings.LastIndexByte(locale, '-')
+		if idx < 0 {
+			break
+		}
+		locale = locale[:idx]
+	}
+	return fallback
+}
+
+// normalizeLocale returns the locale in lower case with '-' as separator (pt_BR becomes pt-br)
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
@@ -1,7 +1,9 @@
 // The package contains base structs and predefined error messages to be used with zerror package
-// additional errormessages can be registered in the main registeredErrorsMap using zerror.RegisterErrors
+// additional errormessages can be registered in the main registry using errormessage.RegisterErrors
 package errormessage
 
+import "net/http"
+
 // Error messages have code in the format ENTITY_<ATTRIBUTE/VERB>_LIST
 const (
   ErrorGeneric                  = "ERROR_GENERIC"
@@ -10,24 +12,29 @@ const (
   ErrorPanic                    = "ERROR_PANIC"
 )
 
-// RegisteredErrorMap is the main map
+// defaultErrorsMap contains the predefined messages, used as the initial registry content
 var (
-  registeredErrorsMap = map[string]Message{
+  defaultErrorsMap = map[string]Message{
     ErrorGeneric: {
-      Code: ErrorGeneric,
-      Msg:  "An error has occurred",
+      Code:   ErrorGeneric,
+      Msg:    "An error has occurred",
+      Status: http.StatusInternalServerError,
     },
     ErrorGenerateParameterInvalid: {
-      Code: ErrorGenerateParameterInvalid,
-      Msg:  "Unable to generate error element, parameter invalid",
+      Code:   ErrorGenerateParameterInvalid,
+      Msg:    "Unable to generate error element, parameter invalid",
+      Status: http.StatusInternalServerError,
     },
     ErrorInternal: {
-      Code: ErrorInternal,
-      Msg:  "An internal error has occurred",
+      Code:   ErrorInternal,
+      Msg:    "An internal error has occurred",
+      Status: http.StatusInternalServerError,
     },
     ErrorPanic: {
-      Code: ErrorPanic,
-      Msg:  "A fatal error has occurred",
+      Code:     ErrorPanic,
+      Msg:      "A fatal error has occurred",
+      Status:   http.StatusInternalServerError,
+      Severity: SeverityCritical,
     },
   }
 )
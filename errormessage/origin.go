@@ -0,0 +1,16 @@
+package errormessage
+
+// Origin is the provenance of an element, the layer (service, repository...) or depth it was collected from.
+// Origins accumulate as a path with the outermost layer first (service/repository).
+type Origin string
+
+// Join returns the path of the inner origin as seen from o (o/inner), empty origins are skipped
+func (o Origin) Join(inner Origin) Origin {
+	switch {
+	case o == "":
+		return inner
+	case inner == "":
+		return o
+	}
+	return o + "/" + inner
+}
@@ -0,0 +1,195 @@
+package errormessage
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultRegistry is the registry used by the package functions (RegisterErrors, GetRegisteredElement, New)
+var DefaultRegistry = NewRegistry()
+
+// Registry is a catalog of error messages accessible by error code.
+//
+// Reads use the current snapshot without locking, registrations copy the snapshot and publish the updated map atomically.
+type Registry struct {
+	current atomic.Pointer[registrySnapshot] // current snapshot, never modified once published
+	mutex   sync.Mutex                       // serializes the writers so concurrent registrations are not lost
+}
+
+// registrySnapshot holds the registered messages and their translations
+type registrySnapshot struct {
+	messages     map[string]Message           // default messages by code
+	translations map[string]map[string]string // translated messages by code and normalized locale
+}
+
+// registryBatch collects the messages of a single registration before they are published
+type registryBatch struct {
+	messages     map[string]Message
+	translations map[string]map[string]string
+}
+
+// NewRegistry creates a new registry containing the predefined messages, see Register() for the parameter format
+func NewRegistry(args ...any) *Registry {
+	r := &Registry{}
+	r.current.Store(&registrySnapshot{
+		messages:     map[string]Message{},
+		translations: map[string]map[string]string{},
+	})
+	batch := newRegistryBatch()
+	for code, message := range defaultErrorsMap {
+		batch.add(code, message)
+	}
+	r.publish(batch)
+	if len(args) > 0 {
+		r.Register(args...)
+	}
+
+	return r
+}
+
+// Register will add error messages to the registry, see RegisterErrors() for the parameter format.
+//
+// All the messages provided in a single call are published at once, readers will see either none or all of them.
+func (r *Registry) Register(args ...any) {
+	batch := newRegistryBatch()
+	defer r.publish(batch)
+
+	itemLen := len(args)
+	if itemLen == 1 { // fully defined message or a list of elements
+		switch element := args[0].(type) {
+		case []IElement:
+			registerErrorElementList(batch, element...)
+		case IElement:
+			registerErrorElementList(batch, element)
+		case Message:
+			batch.add(element.Code, element)
+		case []Message:
+			for _, msg := range element {
+				batch.add(msg.Code, msg)
+			}
+		case map[string]Message: // most common case
+			for key, value := range element {
+				batch.add(key, value)
+			}
+		case string: // we have an error code or a json/yaml
+			if !registerProcessStringList(batch, element) { // element was not json/yaml, we assume it is a Code
+				newMessage := Message{
+					Code: element,
+				}
+				if itemLen > 1 {
+					if msg, msgOk := args[1].(string); msgOk {
+						newMessage.Msg = msg
+					}
+				}
+				batch.add(element, newMessage)
+			}
+		}
+	}
+	if itemLen > 1 { // we have a potential list of Message, ignoring other types
+		for _, element := range args {
+			switch message := element.(type) {
+			case Message:
+				batch.add(message.Code, message)
+			}
+		}
+	}
+}
+
+// Lookup returns the message registered for code
+func (r *Registry) Lookup(code string) (Message, bool) {
+	message, found := r.current.Load().messages[code]
+	return message, found
+}
+
+// Translate returns the message template registered for code in the requested locale.
+//
+// The locale fallback chain is followed (e.g. pt-BR, pt), then the default message is returned.
+// The result is false if the code is not registered.
+func (r *Registry) Translate(code string, locale string) (string, bool) {
+	snapshot := r.current.Load()
+	message, found := snapshot.messages[code]
+	if translations, translationsFound := snapshot.translations[code]; translationsFound {
+		for _, fallback := range LocaleFallback(locale) {
+			if msg, msgFound := translations[fallback]; msgFound {
+				return msg, found
+			}
+		}
+	}
+
+	return message.Msg, found
+}
+
+// Load returns a new element bound to the registry, populated from the message registered for code.
+// If the code is not registered the element will be ErrorGeneric and the result will be false.
+func (r *Registry) Load(code string) (IElement, bool) {
+	errElement := r.New()
+	found := errElement.Load(code)
+
+	return errElement, found
+}
+
+// New will generate a new element bound to the registry, it can be used as ErrorElementGenerator (see New())
+func (r *Registry) New(args ...any) IElement {
+	errElement := &tElement{registry: r}
+	// setting default value
+	errElement.Load(ErrorGeneric)
+	errElement.Trace = captureTrace()
+	errElement.Set(args...)
+
+	return errElement
+}
+
+// publish merges the batch into a copy of the current snapshot and publishes it as a single change
+func (r *Registry) publish(batch *registryBatch) {
+	if len(batch.messages) == 0 && len(batch.translations) == 0 {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	current := r.current.Load()
+	updated := &registrySnapshot{
+		messages:     make(map[string]Message, len(current.messages)+len(batch.messages)),
+		translations: make(map[string]map[string]string, len(current.translations)+len(batch.translations)),
+	}
+	for code, message := range current.messages {
+		updated.messages[code] = message
+	}
+	for code, message := range batch.messages {
+		updated.messages[code] = message
+	}
+	for code, translations := range current.translations {
+		updated.translations[code] = translations
+	}
+	for code, translations := range batch.translations {
+		merged := make(map[string]string, len(updated.translations[code])+len(translations))
+		for locale, msg := range updated.translations[code] {
+			merged[locale] = msg
+		}
+		for locale, msg := range translations {
+			merged[locale] = msg
+		}
+		updated.translations[code] = merged
+	}
+	r.current.Store(updated)
+}
+
+// newRegistryBatch creates an empty registration batch
+func newRegistryBatch() *registryBatch {
+	return &registryBatch{
+		messages:     map[string]Message{},
+		translations: map[string]map[string]string{},
+	}
+}
+
+// add will add the message to the batch, as a translation if the message has a Locale
+func (b *registryBatch) add(code string, message Message) {
+	if message.Locale == "" {
+		b.messages[code] = message
+		return
+	}
+	if _, found := b.translations[code]; !found {
+		b.translations[code] = map[string]string{}
+	}
+	b.translations[code][normalizeLocale(message.Locale)] = message.Msg
+}
@@ -0,0 +1,62 @@
+package errormessage
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity represents the importance of an error element, elements without severity are considered SeverityError
+type Severity uint8
+
+// Severity levels, from the least to the most severe
+const (
+	SeverityDebug Severity = iota + 1
+	SeverityInfo
+	SeverityWarning
+	SeverityError
+	SeverityCritical
+)
+
+// severityNames contains the text representation of the severity levels
+var severityNames = map[Severity]string{
+	SeverityDebug:    "debug",
+	SeverityInfo:     "info",
+	SeverityWarning:  "warning",
+	SeverityError:    "error",
+	SeverityCritical: "critical",
+}
+
+// ParseSeverity returns the severity from its name (debug, info, warning, error, critical), case insensitive
+func ParseSeverity(name string) (Severity, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for severity, severityName := range severityNames {
+		if severityName == name {
+			return severity, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid severity %q", name)
+}
+
+// String returns the severity name, empty if the severity is not defined
+func (s Severity) String() string {
+	return severityNames[s]
+}
+
+// MarshalText encodes the severity by name, used by json and yaml
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText decodes the severity from its name, used by json and yaml
+func (s *Severity) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*s = 0
+		return nil
+	}
+	severity, err := ParseSeverity(string(text))
+	if err != nil {
+		return err
+	}
+	*s = severity
+	return nil
+}
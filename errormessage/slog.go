@@ -0,0 +1,55 @@
+package errormessage
+
+import (
+	"log/slog"
+	"sort"
+	"strconv"
+)
+
+// LogValue implements slog.LogValuer, the element is logged as a group with code, msg, args and, when present, cause and trace
+func (ee *tElement) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("code", ee.Code),
+		slog.String("msg", ee.GetMsg()),
+	}
+	if ee.Field != "" {
+		attrs = append(attrs, slog.String("field", string(ee.Field)))
+	}
+	if ee.Origin != "" {
+		attrs = append(attrs, slog.String("origin", string(ee.Origin)))
+	}
+	if ee.Severity != 0 {
+		attrs = append(attrs, slog.String("severity", ee.Severity.String()))
+	}
+	if len(ee.Args) > 0 {
+		keys := make([]string, 0, len(ee.Args))
+		for key := range ee.Args {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		args := make([]slog.Attr, 0, len(keys))
+		for _, key := range keys {
+			args = append(args, slog.Any(key, ee.Args[key]))
+		}
+		attrs = append(attrs, slog.Attr{Key: "args", Value: slog.GroupValue(args...)})
+	}
+	if ee.Cause != nil {
+		attrs = append(attrs, slog.String("cause", ee.Cause.Error()))
+	}
+	if len(ee.Trace) > 0 {
+		trace := make([]string, 0, len(ee.Trace))
+		for _, frame := range ee.Trace {
+			trace = append(trace, frame.String())
+		}
+		attrs = append(attrs, slog.Any("trace", trace))
+	}
+	if len(ee.Children) > 0 {
+		children := make([]slog.Attr, 0, len(ee.Children))
+		for idx, child := range ee.Children {
+			children = append(children, slog.Any(strconv.Itoa(idx), child))
+		}
+		attrs = append(attrs, slog.Attr{Key: "children", Value: slog.GroupValue(children...)})
+	}
+
+	return slog.GroupValue(attrs...)
+}
@@ -5,7 +5,7 @@ import (
   "gopkg.in/yaml.v3"
 )
 
-// RegisterErrors will add predefined error codes to the main errormessage.RegisteredErrorMap so they can be accessed by error code.
+// RegisterErrors will add predefined error codes to the DefaultRegistry so they can be accessed by error code.
 // This is a wrapper function that can be used in a generic app that loads predefined error messages from various places(packages, config files, etc...).
 //
 // @Params
@@ -18,90 +18,66 @@ import (
 //	    Message     - adds the element to the list
 //	    []map[string]any - imports the list from a generic map if the structure is compatible
 //	    map[string]any   - imports the element from map if compatible
-//	    string | []byte  - assumes this is a json or yaml map or slice
+//	    string | []byte  - assumes this is a json or yaml map or slice, or a translation list in the format
+//	                       {"locale": "pt-BR", "messages": {"ERROR_CODE": {"msg": "translated message"}}}
 //	  multiple arguments - assumes you are registering elements that must be compatible with Message structure
 //      Code, Msg string - register a single message with the properties specfied via these args
 //      Message... - array of messages
+//
+// All the messages provided in a single call are published at once, readers will see either none or all of them.
+// The messages are registered in the DefaultRegistry, see Registry.Register()
 func RegisterErrors(args ...any) {
-  itemLen := len(args)
-  if itemLen == 1 { // fully defined message or a list of elements
-    switch element := args[0].(type) {
-    case []IElement:
-      registerErrorElementList(element...)
-    case IElement:
-      registerErrorElementList(element)
-    case Message:
-      registeredErrorsMap[element.Code] = element
-    case []Message:
-      for _, msg := range element {
-        registeredErrorsMap[msg.Code] = msg
-      }
-    case map[string]Message: // most common case
-      for key, value := range element {
-        registeredErrorsMap[key] = value
-      }
-    case string: // we have an error code or a json/yaml
-      if !registerProcessStringList(element) { // element was not json/yaml, we assume it is a Code
-        newMessage := Message{
-          Code: element,
-        }
-        if itemLen > 1 {
-          if msg, msgOk := args[1].(string); msgOk {
-            newMessage.Msg = msg
-          }
-        }
-        registeredErrorsMap[element] = newMessage
-      }
-    }
-  }
-  if itemLen > 1 { // we have a potential list of Message, ignoring other types
-    for _, element := range args {
-      switch message := element.(type) {
-      case Message:
-        registeredErrorsMap[message.Code] = message
-      }
-    }
-  }
+  DefaultRegistry.Register(args...)
 }
 
-// registerErrorElementList adds IElement items to the registeredErrorsMap
-func registerErrorElementList(args ...IElement) {
+// registerErrorElementList adds IElement items to the registration batch
+func registerErrorElementList(batch *registryBatch, args ...IElement) {
   if len(args) > 0 {
     for _, element := range args {
-      registeredErrorsMap[element.GetCode()] = Message{element.GetCode(), element.GetMsg()}
+      batch.add(element.GetCode(), Message{Code: element.GetCode(), Msg: element.GetMsgTemplate()})
     }
   }
 }
 
-func registerProcessStringList[T string | []byte](config T) bool {
+// registerProcessStringList adds the messages from a json or yaml map or slice to the registration batch
+func registerProcessStringList[T string | []byte](batch *registryBatch, config T) bool {
   listData := ([]byte)(config)
   resultMap := map[string]Message{}
   resultSlice := make([]Message, 0)
+  localeList := localeCatalog{}
+
+  // try to see if it is a translation list
+  if err := json.Unmarshal(listData, &localeList); err == nil && localeList.register(batch) {
+    return true
+  }
+  if err := yaml.Unmarshal(listData, &localeList); err == nil && localeList.register(batch) {
+    return true
+  }
 
   if err := json.Unmarshal(listData, &resultMap); err == nil {
     for key, value := range resultMap {
-      registeredErrorsMap[key] = value
+      batch.add(key, value)
     }
     return true
   }
   // try to marshal to a slice
-  if err := json.Unmarshal(listData, &resultSlice); err != nil {
+  if err := json.Unmarshal(listData, &resultSlice); err == nil {
     for _, elem := range resultSlice {
-      registeredErrorsMap[elem.Code] = elem
+      batch.add(elem.Code, elem)
     }
     return true
   }
   // try to see if it is yaml
   if err := yaml.Unmarshal(listData, &resultMap); err == nil {
     for key, value := range resultMap {
-      registeredErrorsMap[key] = value
+      batch.add(key, value)
     }
     return true
   }
   // try to marshal to a slice
-  if err := yaml.Unmarshal(listData, &resultSlice); err != nil {
+  if err := yaml.Unmarshal(listData, &resultSlice); err == nil {
     for _, elem := range resultSlice {
-      registeredErrorsMap[elem.Code] = elem
+      batch.add(elem.Code, elem)
     }
     return true
   }
@@ -109,12 +85,9 @@ func registerProcessStringList[T string | []byte](config T) bool {
   return false
 }
 
-// GetRegisteredElement returns the element defined by key or will return the ErrorGeneric otherwise
+// GetRegisteredElement returns the element defined by key from the DefaultRegistry or will return the ErrorGeneric otherwise
 func GetRegisteredElement(key string) IElement {
-  elem := New(ErrorGeneric)
-  if !elem.Load(key) {
-    elem.Load(ErrorGeneric)
-  }
+  elem, _ := DefaultRegistry.Load(key)
 
   return elem
 }
@@ -136,13 +109,19 @@ func GetRegisteredElement(key string) IElement {
 //		  errormessage.IElement
 //		    a prefilled IElement we wish to edit
 //		  error
-//			the errElement.Msg will be set to errorItem.Error()
+//			the errElement.Msg will be set to errorItem.Error() and the error is kept as the element Cause
 //		args
 //		  will represent the rest of the params needed to create a new IElement (based on type)
 //		  string
 //		     will set the IElement.Msg field to the specified value
+//		  error
+//		     will set the IElement.Cause, the Msg remains unchanged
 //		  map[string]any
 //		     will add the keys to IElement.Args
+//		  Severity
+//		     will set the IElement severity
+//		  Field
+//		     will set the IElement field path (stored in dotted notation)
 //		  TraceElement, []TraceElement
 //		     will append the TraceElement to IElement.Trace
 //		  other
@@ -0,0 +1,94 @@
+package errormessage
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Message templates contain placeholders in the {name} format that are replaced with the element Args when rendered:
+//
+//	"User length {user_length} is below {expected_length}"
+//
+// Placeholders without a matching key in Args are rendered unchanged, so missing data stays visible.
+
+// RenderMessage replaces the {name} placeholders in the template with the values from args
+func RenderMessage(template string, args map[string]any) string {
+	if !strings.Contains(template, "{") {
+		return template
+	}
+	builder := strings.Builder{}
+	builder.Grow(len(template))
+	for {
+		start, end, name := nextPlaceholder(template)
+		if start < 0 {
+			builder.WriteString(template)
+			break
+		}
+		builder.WriteString(template[:start])
+		if value, found := args[name]; found {
+			builder.WriteString(fmt.Sprint(value))
+		} else {
+			builder.WriteString(template[start:end])
+		}
+		template = template[end:]
+	}
+
+	return builder.String()
+}
+
+// TemplatePlaceholders returns the unique placeholder names used in the template, in order of appearance
+func TemplatePlaceholders(template string) []string {
+	names := make([]string, 0)
+	seen := map[string]bool{}
+	for {
+		start, end, name := nextPlaceholder(template)
+		if start < 0 {
+			break
+		}
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+		template = template[end:]
+	}
+
+	return names
+}
+
+// nextPlaceholder returns the position of the first valid placeholder in the template and its name, start is -1 if none found
+func nextPlaceholder(template string) (start int, end int, name string) {
+	offset := 0
+	for {
+		open := strings.IndexByte(template[offset:], '{')
+		if open < 0 {
+			return -1, -1, ""
+		}
+		open += offset
+		closing := strings.IndexByte(template[open+1:], '}')
+		if closing < 0 {
+			return -1, -1, ""
+		}
+		closing += open + 1
+		name = template[open+1 : closing]
+		if isPlaceholderName(name) {
+			return open, closing + 1, name
+		}
+		offset = open + 1
+	}
+}
+
+// isPlaceholderName returns true if name is a valid placeholder name (letters, digits, '_', '-' and '.')
+func isPlaceholderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, char := range name {
+		switch {
+		case char >= 'a' && char <= 'z', char >= 'A' && char <= 'Z', char >= '0' && char <= '9':
+		case char == '_', char == '-', char == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
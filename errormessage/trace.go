@@ -0,0 +1,82 @@
+package errormessage
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// tracePackagePrefix identifies the frames that belong to the zerror module, they are never part of a trace
+const tracePackagePrefix = "github.com/znxlc/zerror"
+
+var (
+	// TraceDepth is the maximum number of frames recorded when a new element is created (0 disables the trace capture)
+	TraceDepth = 16
+	// TraceSkip is the number of additional caller frames skipped, useful when elements are created through wrapper functions
+	TraceSkip = 0
+	// TraceMarshal will include the trace list in the JSON representation of the elements
+	TraceMarshal = false
+)
+
+// TraceElement represents a single frame of the call stack recorded when an element was created
+type TraceElement struct {
+	Function string `json:"function"` // fully qualified function name
+	File     string `json:"file"`     // source file
+	Line     int    `json:"line"`     // line in the source file
+}
+
+// String returns the frame in the "function file:line" format
+func (te TraceElement) String() string {
+	return fmt.Sprintf("%s %s:%d", te.Function, te.File, te.Line)
+}
+
+// captureTrace records the call stack of the code that created the element.
+// Frames belonging to the zerror packages are skipped so the trace starts with the caller, followed by TraceSkip extra frames.
+func captureTrace() []TraceElement {
+	if TraceDepth <= 0 {
+		return nil
+	}
+	skip := TraceSkip
+	if skip < 0 {
+		skip = 0
+	}
+	// the internal frames are unknown in advance (New, ZError.Add, zerror.New...), so we collect a few more than needed
+	pcs := make([]uintptr, TraceDepth+skip+16)
+	count := runtime.Callers(2, pcs) // skipping runtime.Callers and captureTrace
+	frames := runtime.CallersFrames(pcs[:count])
+
+	trace := make([]TraceElement, 0, TraceDepth)
+	internal := true
+	for {
+		frame, more := frames.Next()
+		if internal && isInternalFrame(frame) {
+			if !more {
+				break
+			}
+			continue
+		}
+		internal = false
+		if skip > 0 {
+			skip--
+		} else {
+			trace = append(trace, TraceElement{
+				Function: frame.Function,
+				File:     frame.File,
+				Line:     frame.Line,
+			})
+		}
+		if !more || len(trace) >= TraceDepth {
+			break
+		}
+	}
+
+	return trace
+}
+
+// isInternalFrame returns true if the frame belongs to one of the zerror packages (test files excluded)
+func isInternalFrame(frame runtime.Frame) bool {
+	if strings.HasSuffix(frame.File, "_test.go") {
+		return false
+	}
+	return strings.HasPrefix(frame.Function, tracePackagePrefix+".") || strings.HasPrefix(frame.Function, tracePackagePrefix+"/")
+}
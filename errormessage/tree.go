@@ -0,0 +1,47 @@
+package errormessage
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WalkFunc is called for every element visited by Walk with its depth (0 for the starting element),
+// returning false skips the children of the element
+type WalkFunc func(element IElement, depth int) bool
+
+// GetChildren returns the child elements, e.g. the field errors owned by ERROR_REQUEST_VALIDATION
+func (ee *tElement) GetChildren() []IElement {
+	return ee.Children
+}
+
+// AddChild appends the elements to the children of the element
+func (ee *tElement) AddChild(children ...IElement) {
+	ee.Children = append(ee.Children, children...)
+}
+
+// Walk visits the element and its descendants depth-first, parents before children
+func Walk(element IElement, fn WalkFunc) {
+	walk(element, 0, fn)
+}
+
+// walk visits the element at depth and its children
+func walk(element IElement, depth int, fn WalkFunc) {
+	if !fn(element, depth) {
+		return
+	}
+	for _, child := range element.GetChildren() {
+		walk(child, depth+1, fn)
+	}
+}
+
+// FormatTree returns the element and its descendants one per line ("code: msg"), indented by two spaces for each depth level,
+// each line is prefixed with indent
+func FormatTree(element IElement, indent string) string {
+	builder := strings.Builder{}
+	Walk(element, func(node IElement, depth int) bool {
+		fmt.Fprintf(&builder, "%s%s%s: %s\n", indent, strings.Repeat("  ", depth), node.GetCode(), node.GetMsg())
+		return true
+	})
+
+	return builder.String()
+}
@@ -0,0 +1,50 @@
+package zerror
+
+import (
+  errormessage "github.com/znxlc/zerror/errormessage"
+)
+
+// Field is the path of the field an element refers to, in dotted or JSON Pointer notation, see errormessage.Field
+type Field = errormessage.Field
+
+// FieldErrors contains the elements grouped by field path (dotted notation), it marshals to the {field: [errors]} JSON shape
+type FieldErrors map[string][]errormessage.IElement
+
+// AddField will append an error element for the field specified, args follow the Add() format.
+// When merging a zerror or a list the field is prepended to the field path of the elements.
+//
+//	ze.AddField("user.email", "ERROR_USER_EMAIL_INVALID", "Email is invalid")
+//	ze.AddField("user.address", validate.Struct(address)) // street becomes user.address.street
+func (ze *ZError) AddField(field string, args ...any) {
+  if len(args) == 0 {
+    args = []any{errormessage.ErrorGeneric}
+  }
+  ze.Add(append(args, Field(field))...)
+}
+
+// GroupByField returns the elements with a field path grouped by field, the elements without field are ignored
+func (ze *ZError) GroupByField() FieldErrors {
+  fieldErrors := FieldErrors{}
+  for _, errElement := range ze.Errors {
+    if field := errElement.GetField(); field != "" {
+      fieldErrors[string(field)] = append(fieldErrors[string(field)], errElement)
+    }
+  }
+  return fieldErrors
+}
+
+// AddField will append an error element for the field specified, see ZError.AddField().
+// It goes through Add() so the zerror arguments are collected before locking.
+func (sze *SyncZError) AddField(field string, args ...any) {
+  if len(args) == 0 {
+    args = []any{errormessage.ErrorGeneric}
+  }
+  sze.Add(append(args, Field(field))...)
+}
+
+// GroupByField returns the elements with a field path grouped by field, see ZError.GroupByField()
+func (sze *SyncZError) GroupByField() FieldErrors {
+  sze.mu.RLock()
+  defer sze.mu.RUnlock()
+  return sze.ze.GroupByField()
+}
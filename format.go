@@ -0,0 +1,54 @@
+package zerror
+
+import (
+  "fmt"
+  "io"
+  "strings"
+
+  errormessage "github.com/znxlc/zerror/errormessage"
+)
+
+// Format implements fmt.Formatter.
+//
+//	%s, %v  the short form (same as Error())
+//	%q      the quoted short form
+//	%+v     multi-line listing of every element with index, code, msg, sorted args, cause and trace
+func (ze *ZError) Format(state fmt.State, verb rune) {
+  formatError(state, verb, ze.Error(), ze.Errors)
+}
+
+// Format implements fmt.Formatter, see ZError.Format()
+func (sze *SyncZError) Format(state fmt.State, verb rune) {
+  sze.mu.RLock()
+  defer sze.mu.RUnlock()
+  sze.ze.Format(state, verb)
+}
+
+// formatError writes the short form or the verbose listing of the error list based on the verb
+func formatError(state fmt.State, verb rune, short string, errList []errormessage.IElement) {
+  switch verb {
+  case 'v':
+    if state.Flag('+') {
+      io.WriteString(state, formatVerbose(errList))
+      return
+    }
+    io.WriteString(state, short)
+  case 's':
+    io.WriteString(state, short)
+  case 'q':
+    fmt.Fprintf(state, "%q", short)
+  default:
+    fmt.Fprintf(state, "%%!%c(%s)", verb, short)
+  }
+}
+
+// formatVerbose returns the multi-line listing of the error list
+func formatVerbose(errList []errormessage.IElement) string {
+  builder := strings.Builder{}
+  fmt.Fprintf(&builder, "errors: %d\n", len(errList))
+  for idx, errElement := range errList {
+    fmt.Fprintf(&builder, "[%d]\n", idx)
+    builder.WriteString(errormessage.FormatVerbose(errElement, "  "))
+  }
+  return builder.String()
+}
@@ -0,0 +1,55 @@
+// Package httpx contains helpers to return zerror errors from net/http handlers.
+//
+// The response status is selected from the HTTP status of the registered messages (see errormessage.Message.Status).
+// Errors can be written as a plain JSON list (WriteError) or as RFC 9457 Problem Details (WriteProblem).
+package httpx
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/znxlc/zerror"
+	"github.com/znxlc/zerror/errormessage"
+)
+
+// DefaultStatus is used for the elements without an HTTP status and for empty error lists
+var DefaultStatus = http.StatusInternalServerError
+
+// Response is the JSON body written by WriteError
+type Response struct {
+	Errors []errormessage.IElement `json:"errors"` // the error list
+}
+
+// Status returns the HTTP status for the zerror.
+//
+// The highest-priority element decides the status, the priority being the status code itself (5xx over 4xx).
+// Elements without a status are considered DefaultStatus.
+func Status(ze zerror.Error) int {
+	status := 0
+	for _, errElement := range ze.GetList() {
+		elementStatus := errElement.GetStatus()
+		if elementStatus == 0 {
+			elementStatus = DefaultStatus
+		}
+		if elementStatus > status {
+			status = elementStatus
+		}
+	}
+	if status == 0 {
+		return DefaultStatus
+	}
+	return status
+}
+
+// WriteError writes the error list as JSON with the status selected by Status()
+func WriteError(w http.ResponseWriter, ze zerror.Error) error {
+	body, err := json.Marshal(Response{Errors: ze.GetList()})
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(Status(ze))
+	_, err = w.Write(body)
+
+	return err
+}
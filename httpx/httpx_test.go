@@ -0,0 +1,83 @@
+package httpx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/znxlc/zerror"
+	"github.com/znxlc/zerror/errormessage"
+)
+
+func TestStatus(t *testing.T) {
+	registry := errormessage.NewRegistry(
+		errormessage.Message{Code: "ERROR_USER_INVALID", Msg: "User is invalid", Status: http.StatusBadRequest},
+		errormessage.Message{Code: "ERROR_USER_NOT_FOUND", Msg: "User not found", Status: http.StatusNotFound},
+	)
+	ze := zerror.New()
+	ze.SetElementGenerator(registry.New)
+	assert.Equal(t, http.StatusInternalServerError, Status(ze))
+
+	ze.Add("ERROR_USER_INVALID")
+	assert.Equal(t, http.StatusBadRequest, Status(ze))
+	ze.Add("ERROR_USER_NOT_FOUND")
+	assert.Equal(t, http.StatusNotFound, Status(ze))
+	ze.Add(errormessage.ErrorInternal)
+	assert.Equal(t, http.StatusInternalServerError, Status(ze))
+
+	// unregistered codes have no status, DefaultStatus applies
+	DefaultStatus = http.StatusBadRequest
+	defer func() { DefaultStatus = http.StatusInternalServerError }()
+	assert.Equal(t, 0, zerror.New("ERROR_CUSTOM").Get().GetStatus())
+	assert.Equal(t, http.StatusBadRequest, Status(zerror.New("ERROR_CUSTOM")))
+}
+
+func TestWriteError(t *testing.T) {
+	registry := errormessage.NewRegistry(errormessage.Message{Code: "ERROR_USER_INVALID", Msg: "User is invalid", Status: http.StatusBadRequest})
+	ze := zerror.New()
+	ze.SetElementGenerator(registry.New)
+	ze.Add("ERROR_USER_INVALID", map[string]any{"user": "test"})
+
+	recorder := httptest.NewRecorder()
+	assert.NoError(t, WriteError(recorder, ze))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"errors":[{"code":"ERROR_USER_INVALID","msg":"User is invalid","args":{"user":"test"},"status":400}]}`, recorder.Body.String())
+}
+
+func TestProblem(t *testing.T) {
+	errormessage.RegisterErrors(errormessage.Message{Code: "ERROR_PROBLEM_LENGTH", Msg: "Length {length} is invalid", Status: http.StatusUnprocessableEntity})
+	ze := zerror.New("ERROR_PROBLEM_LENGTH", map[string]any{"length": 3})
+	ze.Add(errormessage.ErrorGeneric, "second error")
+
+	recorder := httptest.NewRecorder()
+	assert.NoError(t, WriteProblem(recorder, ze))
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	assert.Equal(t, ProblemContentType, recorder.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{
+		"type": "urn:zerror:ERROR_PROBLEM_LENGTH",
+		"title": "Length {length} is invalid",
+		"status": 500,
+		"detail": "Length 3 is invalid",
+		"errors": [
+			{"code": "ERROR_PROBLEM_LENGTH", "msg": "Length 3 is invalid", "args": {"length": 3}, "status": 422},
+			{"code": "ERROR_GENERIC", "msg": "second error", "status": 500}
+		]
+	}`, recorder.Body.String())
+
+	decoded, err := DecodeProblem(recorder.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(decoded.GetList()))
+	assert.Equal(t, "ERROR_PROBLEM_LENGTH", decoded.Get(0).GetCode())
+	assert.Equal(t, "Length 3 is invalid", decoded.Get(0).GetMsg())
+	assert.Equal(t, float64(3), decoded.Get(0).GetArgs()["length"])
+	assert.Equal(t, "second error", decoded.Get(1).GetMsg())
+
+	// the title comes from the registry the element is bound to
+	billing := errormessage.NewRegistry(errormessage.Message{Code: "ERROR_PROBLEM_LENGTH", Msg: "Invoice length {length} is invalid"})
+	bound := zerror.New()
+	bound.SetElementGenerator(billing.New)
+	bound.Add("ERROR_PROBLEM_LENGTH", map[string]any{"length": 3})
+	assert.Equal(t, "Invoice length {length} is invalid", EncodeProblem(bound).Title)
+}
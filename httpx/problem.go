@@ -0,0 +1,103 @@
+package httpx
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/znxlc/zerror"
+	"github.com/znxlc/zerror/errormessage"
+)
+
+// ProblemContentType is the media type of the RFC 9457 Problem Details documents
+const ProblemContentType = "application/problem+json"
+
+// ProblemTypeURI builds the problem "type" member from the error code of the main element
+var ProblemTypeURI = func(code string) string {
+	return "urn:zerror:" + code
+}
+
+// Problem is an RFC 9457 Problem Details document, the element list is stored in the "errors" extension member
+type Problem struct {
+	Type     string           `json:"type"`               // URI identifying the problem type, see ProblemTypeURI
+	Title    string           `json:"title"`              // message registered for the main element in the registry it is bound to
+	Status   int              `json:"status"`             // HTTP status, see Status()
+	Detail   string           `json:"detail,omitempty"`   // rendered message of the main element
+	Instance string           `json:"instance,omitempty"` // optional URI identifying the occurrence
+	Errors   []ProblemElement `json:"errors"`             // the full error list
+}
+
+// ProblemElement is a single element of the Problem "errors" extension member
+type ProblemElement struct {
+	Code   string         `json:"code"`
+	Msg    string         `json:"msg"`
+	Args   map[string]any `json:"args,omitempty"`
+	Status int            `json:"status,omitempty"`
+}
+
+// EncodeProblem converts the zerror to a Problem Details document, the main element is selected by ze.Get()
+func EncodeProblem(ze zerror.Error) Problem {
+	problem := Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(Status(ze)),
+		Status: Status(ze),
+		Errors: make([]ProblemElement, 0, len(ze.GetList())),
+	}
+	if errElement := ze.Get(); errElement != nil {
+		problem.Type = ProblemTypeURI(errElement.GetCode())
+		problem.Title = errElement.GetMsgTemplate()
+		if message, found := errElement.GetRegistry().Lookup(errElement.GetCode()); found {
+			problem.Title = message.Msg
+		}
+		problem.Detail = errElement.GetMsg()
+	}
+	for _, errElement := range ze.GetList() {
+		problem.Errors = append(problem.Errors, ProblemElement{
+			Code:   errElement.GetCode(),
+			Msg:    errElement.GetMsg(),
+			Args:   errElement.GetArgs(),
+			Status: errElement.GetStatus(),
+		})
+	}
+
+	return problem
+}
+
+// WriteProblem writes the zerror as an application/problem+json response
+func WriteProblem(w http.ResponseWriter, ze zerror.Error) error {
+	problem := EncodeProblem(ze)
+	body, err := json.Marshal(problem)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(problem.Status)
+	_, err = w.Write(body)
+
+	return err
+}
+
+// DecodeProblem rebuilds a zerror from a Problem Details document.
+//
+// The elements are restored from the "errors" extension member, documents without it produce a single
+// ErrorGeneric element with the problem detail (or title) as message.
+func DecodeProblem(r io.Reader) (zerror.Error, error) {
+	problem := Problem{}
+	if err := json.NewDecoder(r).Decode(&problem); err != nil {
+		return nil, err
+	}
+
+	ze := zerror.New()
+	for _, element := range problem.Errors {
+		ze.Add(element.Code, element.Msg, element.Args)
+	}
+	if !ze.HasErrors() {
+		msg := problem.Detail
+		if msg == "" {
+			msg = problem.Title
+		}
+		ze.Add(errormessage.ErrorGeneric, msg)
+	}
+
+	return ze, nil
+}
@@ -0,0 +1,62 @@
+package zerror
+
+import (
+  "encoding/json"
+
+  errormessage "github.com/znxlc/zerror/errormessage"
+)
+
+// jsonZError is the JSON representation of the ZError
+type jsonZError struct {
+  Errors []errormessage.IElement `json:"errors"`
+}
+
+// MarshalJSON will return the Errors list in the {"errors": [...]} format
+func (ze *ZError) MarshalJSON() ([]byte, error) {
+  return json.Marshal(jsonZError{Errors: ze.Errors})
+}
+
+// UnmarshalJSON will replace the Errors list with the decoded elements, each element is created via ElementGenerator
+func (ze *ZError) UnmarshalJSON(data []byte) error {
+  decoded := struct {
+    Errors []json.RawMessage `json:"errors"`
+  }{}
+  if err := json.Unmarshal(data, &decoded); err != nil {
+    return err
+  }
+  if ze.ElementGenerator == nil {
+    ze.ElementGenerator = DefaultElementGenerator
+  }
+  if ze.ElementIndexReturned == "" {
+    ze.ElementIndexReturned = ElementIndexReturned
+  }
+
+  errList := make([]errormessage.IElement, 0, len(decoded.Errors))
+  for _, data := range decoded.Errors {
+    errElement := ze.ElementGenerator()
+    if err := errElement.UnmarshalJSON(data); err != nil {
+      return err
+    }
+    errList = append(errList, errElement)
+  }
+  ze.Errors = errList
+
+  return nil
+}
+
+// MarshalJSON will return the Errors list in the {"errors": [...]} format
+func (sze *SyncZError) MarshalJSON() ([]byte, error) {
+  sze.mu.RLock()
+  defer sze.mu.RUnlock()
+  return sze.ze.MarshalJSON()
+}
+
+// UnmarshalJSON will replace the Errors list with the decoded elements, see ZError.UnmarshalJSON()
+func (sze *SyncZError) UnmarshalJSON(data []byte) error {
+  sze.mu.Lock()
+  defer sze.mu.Unlock()
+  if sze.ze == nil {
+    sze.ze = &ZError{}
+  }
+  return sze.ze.UnmarshalJSON(data)
+}
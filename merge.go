@@ -0,0 +1,80 @@
+package zerror
+
+import (
+  "errors"
+  "reflect"
+
+  errormessage "github.com/znxlc/zerror/errormessage"
+)
+
+// joinErrorType is the concrete type of the errors returned by errors.Join
+var joinErrorType = reflect.TypeOf(errors.Join(errors.New("joined")))
+
+// collectElements returns the elements of a list, a zerror or joined errors (errors.Join), the result is false for other types.
+//
+// Joined errors are flattened: zerrors and nested joins contribute their elements, elements are kept as they are
+// and any other error is converted to an element using generator.
+// Other wrapping errors (fmt.Errorf("repo: %w", ze), fmt.Errorf("load: %w, %w", a, b)) are not flattened,
+// they become a single element keeping the error as Cause so the wrapper message is not lost.
+func collectElements(item any, generator errormessage.ErrorElementGenerator) ([]errormessage.IElement, bool) {
+  switch element := item.(type) {
+  case []errormessage.IElement:
+    return element, true
+  case Error:
+    return element.GetList(), true
+  case errormessage.IElement: // single elements are handled by the generator
+    return nil, false
+  case interface{ Unwrap() []error }:
+    if reflect.TypeOf(element) != joinErrorType {
+      return nil, false
+    }
+    errList := make([]errormessage.IElement, 0)
+    for _, err := range element.Unwrap() {
+      if err == nil {
+        continue
+      }
+      if nested, ok := collectElements(err, generator); ok {
+        errList = append(errList, nested...)
+        continue
+      }
+      if errElement, ok := err.(errormessage.IElement); ok {
+        errList = append(errList, errElement)
+        continue
+      }
+      errList = append(errList, generator(err))
+    }
+    return errList, true
+  }
+
+  return nil, false
+}
+
+// merge appends the elements to the Errors list, when args contains an Origin or a Field the elements are copied and tagged:
+// the origin is prepended to the element origin and the field to the element field path (address + street = address.street)
+func (ze *ZError) merge(errList []errormessage.IElement, args []any) {
+  origin := Origin("")
+  field := Field("")
+  for _, arg := range args {
+    switch value := arg.(type) {
+    case Origin:
+      origin = origin.Join(value)
+    case Field:
+      field = Field(value.Dotted())
+    }
+  }
+  if origin == "" && field == "" {
+    ze.Errors = append(ze.Errors, errList...)
+    return
+  }
+  for _, errElement := range errList {
+    tags := []any{errElement, origin}
+    if field != "" {
+      path := field
+      if elementField := errElement.GetField(); elementField != "" {
+        path = field + "." + elementField
+      }
+      tags = append(tags, path)
+    }
+    ze.Errors = append(ze.Errors, ze.ElementGenerator(tags...))
+  }
+}
@@ -0,0 +1,110 @@
+package zerror
+
+import (
+  errormessage "github.com/znxlc/zerror/errormessage"
+)
+
+// Filter returns the elements for which predicate returns true, in list order
+func (ze *ZError) Filter(predicate func(errormessage.IElement) bool) []errormessage.IElement {
+  errList := make([]errormessage.IElement, 0)
+  for _, errElement := range ze.Errors {
+    if predicate(errElement) {
+      errList = append(errList, errElement)
+    }
+  }
+  return errList
+}
+
+// Find returns every element with the code specified, in list order
+func (ze *ZError) Find(errCode string) []errormessage.IElement {
+  return ze.Filter(func(errElement errormessage.IElement) bool {
+    return errElement.GetCode() == errCode
+  })
+}
+
+// Remove will remove every element with the code specified and returns the number of elements removed
+func (ze *ZError) Remove(errCode string) int {
+  errList := make([]errormessage.IElement, 0, len(ze.Errors))
+  for _, errElement := range ze.Errors {
+    if errElement.GetCode() != errCode {
+      errList = append(errList, errElement)
+    }
+  }
+  removed := len(ze.Errors) - len(errList)
+  ze.Errors = errList
+  return removed
+}
+
+// Codes returns the unique codes in the Errors list, in order of first appearance
+func (ze *ZError) Codes() []string {
+  codes := make([]string, 0, len(ze.Errors))
+  seen := map[string]bool{}
+  for _, errElement := range ze.Errors {
+    if code := errElement.GetCode(); !seen[code] {
+      seen[code] = true
+      codes = append(codes, code)
+    }
+  }
+  return codes
+}
+
+// CountByCode returns the number of elements for each code
+func (ze *ZError) CountByCode() map[string]int {
+  counts := map[string]int{}
+  for _, errElement := range ze.Errors {
+    counts[errElement.GetCode()]++
+  }
+  return counts
+}
+
+// GroupBy returns the elements grouped by the key returned by keyFunc, elements keep the list order inside each group
+func (ze *ZError) GroupBy(keyFunc func(errormessage.IElement) string) map[string][]errormessage.IElement {
+  groups := map[string][]errormessage.IElement{}
+  for _, errElement := range ze.Errors {
+    key := keyFunc(errElement)
+    groups[key] = append(groups[key], errElement)
+  }
+  return groups
+}
+
+// Filter returns the elements for which predicate returns true, see ZError.Filter()
+func (sze *SyncZError) Filter(predicate func(errormessage.IElement) bool) []errormessage.IElement {
+  sze.mu.RLock()
+  defer sze.mu.RUnlock()
+  return sze.ze.Filter(predicate)
+}
+
+// Find returns every element with the code specified, see ZError.Find()
+func (sze *SyncZError) Find(errCode string) []errormessage.IElement {
+  sze.mu.RLock()
+  defer sze.mu.RUnlock()
+  return sze.ze.Find(errCode)
+}
+
+// Remove will remove every element with the code specified, see ZError.Remove()
+func (sze *SyncZError) Remove(errCode string) int {
+  sze.mu.Lock()
+  defer sze.mu.Unlock()
+  return sze.ze.Remove(errCode)
+}
+
+// Codes returns the unique codes in the Errors list, see ZError.Codes()
+func (sze *SyncZError) Codes() []string {
+  sze.mu.RLock()
+  defer sze.mu.RUnlock()
+  return sze.ze.Codes()
+}
+
+// CountByCode returns the number of elements for each code, see ZError.CountByCode()
+func (sze *SyncZError) CountByCode() map[string]int {
+  sze.mu.RLock()
+  defer sze.mu.RUnlock()
+  return sze.ze.CountByCode()
+}
+
+// GroupBy returns the elements grouped by the key returned by keyFunc, see ZError.GroupBy()
+func (sze *SyncZError) GroupBy(keyFunc func(errormessage.IElement) string) map[string][]errormessage.IElement {
+  sze.mu.RLock()
+  defer sze.mu.RUnlock()
+  return sze.ze.GroupBy(keyFunc)
+}
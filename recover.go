@@ -0,0 +1,68 @@
+package zerror
+
+import (
+  "fmt"
+  "runtime/debug"
+
+  errormessage "github.com/znxlc/zerror/errormessage"
+)
+
+// Recover converts a panic into a zerror stored in *errp, it must be deferred directly:
+//
+//	func Process() (err error) {
+//	  defer zerror.Recover(&err)
+//	  ...
+//	}
+//
+// The zerror holds an ERROR_PANIC element with the panic value ("panic"), its type ("type") and the goroutine
+// stack ("stack") in Args, the panic value is kept as Cause when it is an error (the elements of a zerror become children).
+// An error already stored in *errp is kept after the panic element (zerrors and joined errors are merged, see Add()).
+// Nothing changes if there is no panic. With a nil errp the zerror could not be returned,
+// so the panic is propagated (re-panicking with the same value), use RecoverWith() to only handle it.
+func Recover(errp *error) {
+  if value := recover(); value != nil {
+    if errp == nil {
+      panic(value)
+    }
+    recoverPanic(value, errp)
+  }
+}
+
+// RecoverWith converts a panic into a zerror like Recover() and calls handler with it, errp can be nil:
+//
+//	defer zerror.RecoverWith(nil, func(ze zerror.Error) {
+//	  logger.Error("job failed", "error", ze)
+//	})
+func RecoverWith(errp *error, handler func(Error)) {
+  if value := recover(); value != nil {
+    ze := recoverPanic(value, errp)
+    if handler != nil {
+      handler(ze)
+    }
+  }
+}
+
+// recoverPanic builds the ERROR_PANIC zerror for the recovered value and stores it in errp, if not nil
+func recoverPanic(value any, errp *error) Error {
+  args := []any{
+    errormessage.ErrorPanic,
+    map[string]any{
+      "panic": value,
+      "type":  fmt.Sprintf("%T", value),
+      "stack": string(debug.Stack()),
+    },
+  }
+  if cause, ok := value.(error); ok {
+    args = append(args, cause)
+  }
+  ze := New(args...)
+  if errp == nil {
+    return ze
+  }
+  if *errp != nil {
+    ze.Add(*errp)
+  }
+  *errp = ze
+
+  return ze
+}
@@ -0,0 +1,96 @@
+package zerror
+
+import (
+  "context"
+  "errors"
+  "log/slog"
+  "strconv"
+)
+
+// LogValue implements slog.LogValuer, each element is logged as a group keyed by its index in the Errors list
+func (ze *ZError) LogValue() slog.Value {
+  attrs := make([]slog.Attr, 0, len(ze.Errors))
+  for idx, errElement := range ze.Errors {
+    value := slog.AnyValue(errElement)
+    if valuer, ok := errElement.(slog.LogValuer); ok {
+      value = valuer.LogValue()
+    }
+    attrs = append(attrs, slog.Attr{Key: strconv.Itoa(idx), Value: value})
+  }
+  return slog.GroupValue(attrs...)
+}
+
+// LogValue implements slog.LogValuer, see ZError.LogValue()
+func (sze *SyncZError) LogValue() slog.Value {
+  sze.mu.RLock()
+  defer sze.mu.RUnlock()
+  return sze.ze.LogValue()
+}
+
+// SlogHandler is a slog.Handler wrapper that expands the error attributes containing a zerror (also when wrapped)
+type SlogHandler struct {
+  next slog.Handler
+}
+
+// NewSlogHandler creates a new SlogHandler writing the records to next
+func NewSlogHandler(next slog.Handler) *SlogHandler {
+  return &SlogHandler{next: next}
+}
+
+// Enabled reports whether the next handler handles records at the given level
+func (sh *SlogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+  return sh.next.Enabled(ctx, level)
+}
+
+// Handle expands the zerror attributes of the record and passes it to the next handler
+func (sh *SlogHandler) Handle(ctx context.Context, record slog.Record) error {
+  expanded := slog.NewRecord(record.Time, record.Level, record.Message, record.PC)
+  record.Attrs(func(attr slog.Attr) bool {
+    expanded.AddAttrs(expandErrorAttr(attr))
+    return true
+  })
+  return sh.next.Handle(ctx, expanded)
+}
+
+// WithAttrs returns a new SlogHandler with the expanded attributes added to the next handler
+func (sh *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+  expanded := make([]slog.Attr, 0, len(attrs))
+  for _, attr := range attrs {
+    expanded = append(expanded, expandErrorAttr(attr))
+  }
+  return &SlogHandler{next: sh.next.WithAttrs(expanded)}
+}
+
+// WithGroup returns a new SlogHandler with the group added to the next handler
+func (sh *SlogHandler) WithGroup(name string) slog.Handler {
+  return &SlogHandler{next: sh.next.WithGroup(name)}
+}
+
+// expandErrorAttr replaces an error attribute containing a zerror with the zerror group, groups are processed recursively.
+// If the zerror is wrapped, the full error text is kept in the "error" key of the group.
+func expandErrorAttr(attr slog.Attr) slog.Attr {
+  switch attr.Value.Kind() {
+  case slog.KindGroup:
+    groupAttrs := attr.Value.Group()
+    expanded := make([]slog.Attr, 0, len(groupAttrs))
+    for _, groupAttr := range groupAttrs {
+      expanded = append(expanded, expandErrorAttr(groupAttr))
+    }
+    return slog.Attr{Key: attr.Key, Value: slog.GroupValue(expanded...)}
+  case slog.KindAny:
+    err, isError := attr.Value.Any().(error)
+    if !isError {
+      return attr
+    }
+    var ze Error
+    if !errors.As(err, &ze) {
+      return attr
+    }
+    value := ze.LogValue()
+    if err != ze {
+      value = slog.GroupValue(append([]slog.Attr{slog.String("error", err.Error())}, value.Group()...)...)
+    }
+    return slog.Attr{Key: attr.Key, Value: value}
+  }
+  return attr
+}
@@ -1,15 +1,21 @@
 package zerror
 
-import "github.com/znxlc/zerror/errormessage"
+import (
+  "fmt"
+  "log/slog"
+
+  "github.com/znxlc/zerror/errormessage"
+)
 
 // this file contains types and variables used by the package
 
 // Error flags and predefined codes
 const (
-  FlagReturnLastErrorElement  = "LAST"  // returns the last error in the list by default (Get() and Error() functions)
-  FlagReturnFirstErrorElement = "FIRST" // returns the first element in the list when calling Get() and Error()
-  FlagReturnErrorCode         = "CODE"  // default text returned when calling Error()
-  FlagReturnErrorMsg          = "MSG"   // return Msg field when calling Error()
+  FlagReturnLastErrorElement       = "LAST"   // returns the last error in the list by default (Get() and Error() functions)
+  FlagReturnFirstErrorElement      = "FIRST"  // returns the first element in the list when calling Get() and Error()
+  FlagReturnMostSevereErrorElement = "SEVERE" // returns the most severe element (the first one if more share the same severity) when calling Get() and Error()
+  FlagReturnErrorCode              = "CODE"   // default text returned when calling Error()
+  FlagReturnErrorMsg               = "MSG"    // return Msg field when calling Error()
 )
 
 var (
@@ -21,19 +27,49 @@ var (
   DefaultElementGenerator = errormessage.New
 )
 
+// Code is an error code usable as errors.Is target, errors.Is(ze, zerror.Code("ERROR_CODE")) matches any element with that code
+type Code = errormessage.Code
+
+// Origin tags the elements merged by Add() with the layer they were collected from, see errormessage.Origin
+type Origin = errormessage.Origin
+
 // ZError is the main error structure of the package
 type ZError struct {
   ElementIndexReturned string                             `json:"-"` // set the default element to be returned when calling Get() or Error()
-  ElementGenerator     errormessage.ErrorElementGenerator // the generator for the error elements (pointer to the New() constructor)
+  ElementGenerator     errormessage.ErrorElementGenerator `json:"-"` // the generator for the error elements (pointer to the New() constructor)
   Errors               []errormessage.IElement            `json:"errors"` // the error list
 }
 
+// Error is the interface implemented by ZError and SyncZError
 type Error interface {
   Add(...any)
+  AddField(string, ...any)
   Clear()
+  Codes() []string
+  CountByCode() map[string]int
+  CountMatch(string) int
+  CountPrefix(string) int
   Error() string
-  GetList() []errormessage.IElement
+  Filter(func(errormessage.IElement) bool) []errormessage.IElement
+  Find(string) []errormessage.IElement
+  Format(fmt.State, rune)
   Get(...int) errormessage.IElement
-  HasErrors() bool
+  GetList() []errormessage.IElement
+  GroupBy(func(errormessage.IElement) string) map[string][]errormessage.IElement
+  GroupByField() FieldErrors
+  Has(string) bool
+  HasErrors(...errormessage.Severity) bool
+  HasMatch(string) bool
+  HasPrefix(string) bool
+  Is(error) bool
+  Localize(string) Error
+  LogValue() slog.Value
+  MarshalJSON() ([]byte, error)
+  Remove(string) int
   SetDefaultElementIndexReturned(string)
+  SetElementGenerator(errormessage.ErrorElementGenerator)
+  Tree() string
+  UnmarshalJSON([]byte) error
+  Unwrap() []error
+  Walk(errormessage.WalkFunc)
 }
@@ -0,0 +1,155 @@
+package zerror
+
+import (
+  "sync"
+
+  errormessage "github.com/znxlc/zerror/errormessage"
+)
+
+// SyncZError is a concurrency-safe ZError, it can be shared by multiple goroutines collecting errors
+type SyncZError struct {
+  mu sync.RWMutex
+  ze *ZError
+}
+
+// NewSync creates a new concurrency-safe zerror instance, see Add() for the parameter format.
+func NewSync(args ...any) Error {
+  sze := &SyncZError{
+    ze: New().(*ZError),
+  }
+  if len(args) > 0 {
+    sze.ze.Add(args...)
+  }
+
+  return sze
+}
+
+// Add will append an error element to the Errors list, see ZError.Add()
+func (sze *SyncZError) Add(args ...any) {
+  if len(args) > 0 {
+    // merged zerrors and zerrors passed as children are collected before locking, they can be sze itself
+    // or a SyncZError adding sze concurrently
+    sze.mu.RLock()
+    generator := sze.ze.ElementGenerator
+    sze.mu.RUnlock()
+    args = append([]any(nil), args...)
+    if errList, ok := collectElements(args[0], generator); ok {
+      args[0] = errList
+    }
+    for idx, arg := range args[1:] {
+      if children, ok := arg.(interface{ GetList() []errormessage.IElement }); ok {
+        args[idx+1] = children.GetList()
+      }
+    }
+  }
+  sze.mu.Lock()
+  defer sze.mu.Unlock()
+  sze.ze.Add(args...)
+}
+
+// Clear will reset the Errors list to an empty list
+func (sze *SyncZError) Clear() {
+  sze.mu.Lock()
+  defer sze.mu.Unlock()
+  sze.ze.Clear()
+}
+
+// Error will return a specific element wrapped as an error string, see ZError.Error()
+func (sze *SyncZError) Error() string {
+  sze.mu.RLock()
+  defer sze.mu.RUnlock()
+  return sze.ze.Error()
+}
+
+// Get returns the IElement specified, see ZError.Get()
+func (sze *SyncZError) Get(index ...int) errormessage.IElement {
+  sze.mu.RLock()
+  defer sze.mu.RUnlock()
+  return sze.ze.Get(index...)
+}
+
+// GetList returns a snapshot of the list of errors, later changes will not be reflected in the returned list
+func (sze *SyncZError) GetList() []errormessage.IElement {
+  sze.mu.RLock()
+  defer sze.mu.RUnlock()
+  errList := make([]errormessage.IElement, len(sze.ze.Errors))
+  copy(errList, sze.ze.Errors)
+  return errList
+}
+
+// Has will return true if the Errors list contains the code specified
+func (sze *SyncZError) Has(errCode string) bool {
+  sze.mu.RLock()
+  defer sze.mu.RUnlock()
+  return sze.ze.Has(errCode)
+}
+
+// HasMatch will return true if the Errors list contains a code matching the pattern, see ZError.HasMatch()
+func (sze *SyncZError) HasMatch(pattern string) bool {
+  sze.mu.RLock()
+  defer sze.mu.RUnlock()
+  return sze.ze.HasMatch(pattern)
+}
+
+// CountMatch returns the number of elements with a code matching the pattern, see ZError.CountMatch()
+func (sze *SyncZError) CountMatch(pattern string) int {
+  sze.mu.RLock()
+  defer sze.mu.RUnlock()
+  return sze.ze.CountMatch(pattern)
+}
+
+// HasPrefix will return true if the Errors list contains a code of the family, see ZError.HasPrefix()
+func (sze *SyncZError) HasPrefix(family string) bool {
+  sze.mu.RLock()
+  defer sze.mu.RUnlock()
+  return sze.ze.HasPrefix(family)
+}
+
+// CountPrefix returns the number of elements with a code of the family, see ZError.CountPrefix()
+func (sze *SyncZError) CountPrefix(family string) int {
+  sze.mu.RLock()
+  defer sze.mu.RUnlock()
+  return sze.ze.CountPrefix(family)
+}
+
+// HasErrors will return true if the Errors list contains elements, see ZError.HasErrors()
+func (sze *SyncZError) HasErrors(minSeverity ...errormessage.Severity) bool {
+  sze.mu.RLock()
+  defer sze.mu.RUnlock()
+  return sze.ze.HasErrors(minSeverity...)
+}
+
+// Is reports whether the Errors list contains an element matching target, see ZError.Is()
+func (sze *SyncZError) Is(target error) bool {
+  sze.mu.RLock()
+  defer sze.mu.RUnlock()
+  return sze.ze.Is(target)
+}
+
+// Localize returns a copy of the zerror with the element messages translated in the requested locale, see ZError.Localize()
+func (sze *SyncZError) Localize(locale string) Error {
+  sze.mu.RLock()
+  defer sze.mu.RUnlock()
+  return sze.ze.Localize(locale)
+}
+
+// SetDefaultElementIndexReturned will set the default element returned when using Get() or Error()
+func (sze *SyncZError) SetDefaultElementIndexReturned(flag string) {
+  sze.mu.Lock()
+  defer sze.mu.Unlock()
+  sze.ze.SetDefaultElementIndexReturned(flag)
+}
+
+// SetElementGenerator will set the generator used to create new elements, see ZError.SetElementGenerator()
+func (sze *SyncZError) SetElementGenerator(generator errormessage.ErrorElementGenerator) {
+  sze.mu.Lock()
+  defer sze.mu.Unlock()
+  sze.ze.SetElementGenerator(generator)
+}
+
+// Unwrap returns a snapshot of the Errors list as a slice of errors
+func (sze *SyncZError) Unwrap() []error {
+  sze.mu.RLock()
+  defer sze.mu.RUnlock()
+  return sze.ze.Unwrap()
+}
@@ -0,0 +1,38 @@
+package zerror
+
+import (
+  "strings"
+
+  errormessage "github.com/znxlc/zerror/errormessage"
+)
+
+// Walk visits every element of the Errors list and their children depth-first, see errormessage.Walk().
+// The top level elements have depth 0, returning false from fn skips the children of the element.
+func (ze *ZError) Walk(fn errormessage.WalkFunc) {
+  for _, errElement := range ze.Errors {
+    errormessage.Walk(errElement, fn)
+  }
+}
+
+// Tree returns the elements one per line ("code: msg"), the children indented by two spaces for each level
+func (ze *ZError) Tree() string {
+  builder := strings.Builder{}
+  for _, errElement := range ze.Errors {
+    builder.WriteString(errormessage.FormatTree(errElement, ""))
+  }
+  return builder.String()
+}
+
+// Walk visits every element and their children depth-first, see ZError.Walk()
+func (sze *SyncZError) Walk(fn errormessage.WalkFunc) {
+  sze.mu.RLock()
+  defer sze.mu.RUnlock()
+  sze.ze.Walk(fn)
+}
+
+// Tree returns the indented elements tree, see ZError.Tree()
+func (sze *SyncZError) Tree() string {
+  sze.mu.RLock()
+  defer sze.mu.RUnlock()
+  return sze.ze.Tree()
+}
@@ -0,0 +1,331 @@
+// Package validate validates structs using the zv struct tag and collects every failure into a single zerror.
+//
+//	type TestRequest struct {
+//	  User  string `json:"user" zv:"required,min=8"`
+//	  Email string `json:"email" zv:"required,email"`
+//	}
+//
+//	ze := validate.Struct(req)
+//	if ze.HasErrors() {
+//	  ...
+//	}
+//
+// Supported rules:
+//
+//	required       the value must not be empty (zero value, nil or zero length)
+//	min=N, max=N   minimum/maximum length for strings, slices and maps, minimum/maximum value for numbers
+//	len=N          exact length for strings, slices and maps
+//	email          the string must be a valid email address
+//	oneof=A B C    the value must be one of the space separated values
+//
+// Missing values (nil, empty strings, slices and maps) are checked only by the required rule, which also rejects zero values.
+// Nested structs, slices, arrays and maps are walked, each failure has the field path (dotted notation, based on the json tag
+// when defined) and the limits in Args.
+package validate
+
+import (
+	"fmt"
+	"net/mail"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/znxlc/zerror"
+	"github.com/znxlc/zerror/errormessage"
+)
+
+// TagName is the struct tag containing the validation rules
+const TagName = "zv"
+
+// Error codes used by the validation failures
+const (
+	ErrorValidateRequired      = "ERROR_VALIDATE_REQUIRED"
+	ErrorValidateLengthMin     = "ERROR_VALIDATE_LENGTH_MIN"
+	ErrorValidateLengthMax     = "ERROR_VALIDATE_LENGTH_MAX"
+	ErrorValidateLength        = "ERROR_VALIDATE_LENGTH_INVALID"
+	ErrorValidateValueMin      = "ERROR_VALIDATE_VALUE_MIN"
+	ErrorValidateValueMax      = "ERROR_VALIDATE_VALUE_MAX"
+	ErrorValidateValueInvalid  = "ERROR_VALIDATE_VALUE_INVALID"
+	ErrorValidateEmailInvalid  = "ERROR_VALIDATE_EMAIL_INVALID"
+	ErrorValidateRuleInvalid   = "ERROR_VALIDATE_RULE_INVALID"
+	ErrorValidateTargetInvalid = "ERROR_VALIDATE_TARGET_INVALID"
+)
+
+func init() {
+	errormessage.RegisterErrors([]errormessage.Message{
+		{Code: ErrorValidateRequired, Msg: "Field {field} is required", Status: 400},
+		{Code: ErrorValidateLengthMin, Msg: "Field {field} length {length} is below {expected_length}", Status: 400},
+		{Code: ErrorValidateLengthMax, Msg: "Field {field} length {length} is above {expected_length}", Status: 400},
+		{Code: ErrorValidateLength, Msg: "Field {field} length {length} is different from {expected_length}", Status: 400},
+		{Code: ErrorValidateValueMin, Msg: "Field {field} value {value} is below {expected_min}", Status: 400},
+		{Code: ErrorValidateValueMax, Msg: "Field {field} value {value} is above {expected_max}", Status: 400},
+		{Code: ErrorValidateValueInvalid, Msg: "Field {field} value {value} is not one of {expected_values}", Status: 400},
+		{Code: ErrorValidateEmailInvalid, Msg: "Field {field} is not a valid email address", Status: 400},
+		{Code: ErrorValidateRuleInvalid, Msg: "Field {field} has an invalid validation rule {rule}", Status: 500},
+		{Code: ErrorValidateTargetInvalid, Msg: "Validation target must be a struct, got {type}", Status: 500},
+	})
+}
+
+// Struct validates the struct (or pointer to struct) and returns a zerror containing every failure,
+// the returned zerror is never nil so the result can be checked with HasErrors()
+func Struct(target any) zerror.Error {
+	ze := zerror.New()
+	value := reflect.ValueOf(target)
+	for value.Kind() == reflect.Pointer && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		ze.Add(ErrorValidateTargetInvalid, map[string]any{"type": fmt.Sprintf("%T", target)})
+		return ze
+	}
+	w := walker{ze: ze, visiting: map[visit]bool{}}
+	w.walkStruct(value, "")
+
+	return ze
+}
+
+// visit identifies a pointer, map or slice like reflect.DeepEqual does: the address alone is not enough,
+// a struct and its first field share the address, slices sharing the array with a different length are different values
+type visit struct {
+	pointer   uintptr
+	length    int
+	valueType reflect.Type
+}
+
+// walker validates the nested values, visiting contains the pointers, maps and slices on the current path
+// so cyclic values (n.Next = n) are walked only once
+type walker struct {
+	ze       zerror.Error
+	visiting map[visit]bool
+}
+
+// walkStruct validates the fields of the struct value, path is the struct field path
+func (w *walker) walkStruct(value reflect.Value, path string) {
+	valueType := value.Type()
+	for idx := 0; idx < valueType.NumField(); idx++ {
+		structField := valueType.Field(idx)
+		tag := structField.Tag.Get(TagName)
+		// embedded structs of unexported types are walked for their promoted fields, like encoding/json
+		embedded := structField.Anonymous && tag == "" && indirect(value.Field(idx)).Kind() == reflect.Struct
+		if embedded && !structField.IsExported() && structField.Type.Kind() != reflect.Struct {
+			continue // the fields of an unexported embedded pointer are not accessible
+		}
+		if (!structField.IsExported() && !embedded) || tag == "-" {
+			continue
+		}
+		fieldValue := value.Field(idx)
+		if embedded {
+			w.walkValue(fieldValue, path) // embedded structs share the parent path
+			continue
+		}
+		fieldPath := joinPath(path, fieldName(structField))
+		if tag != "" {
+			validateRules(w.ze, fieldValue, fieldPath, tag)
+		}
+		w.walkValue(fieldValue, fieldPath)
+	}
+}
+
+// walkValue walks the nested structs, slices, arrays and maps of the value
+func (w *walker) walkValue(value reflect.Value, path string) {
+	for (value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface) && !value.IsNil() {
+		if value.Kind() == reflect.Pointer {
+			if !w.enter(value) {
+				return
+			}
+			defer w.leave(value)
+		}
+		value = value.Elem()
+	}
+	switch value.Kind() {
+	case reflect.Struct:
+		w.walkStruct(value, path)
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice {
+			if !w.enter(value) {
+				return
+			}
+			defer w.leave(value)
+		}
+		for idx := 0; idx < value.Len(); idx++ {
+			w.walkValue(value.Index(idx), joinPath(path, strconv.Itoa(idx)))
+		}
+	case reflect.Map:
+		if !w.enter(value) {
+			return
+		}
+		defer w.leave(value)
+		keys := value.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		for _, key := range keys {
+			w.walkValue(value.MapIndex(key), joinPath(path, fmt.Sprint(key.Interface())))
+		}
+	}
+}
+
+// enter marks the pointer, map or slice as visited, returns false if it is already on the current path (cycle)
+func (w *walker) enter(value reflect.Value) bool {
+	key := visitKey(value)
+	if w.visiting[key] {
+		return false
+	}
+	w.visiting[key] = true
+	return true
+}
+
+// leave removes the pointer, map or slice from the current path
+func (w *walker) leave(value reflect.Value) {
+	delete(w.visiting, visitKey(value))
+}
+
+// visitKey returns the visit identifying the pointer, map or slice
+func visitKey(value reflect.Value) visit {
+	key := visit{pointer: value.Pointer(), valueType: value.Type()}
+	if value.Kind() == reflect.Slice {
+		key.length = value.Len()
+	}
+	return key
+}
+
+// validateRules applies the rules of the tag to the value, each failure is added to ze
+func validateRules(ze zerror.Error, value reflect.Value, path string, tag string) {
+	rules := strings.Split(tag, ",")
+	for _, rule := range rules {
+		name, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		if name == "required" {
+			if isEmpty(value) {
+				ze.AddField(path, ErrorValidateRequired, map[string]any{"field": path})
+				return
+			}
+			continue
+		}
+		if isMissing(value) {
+			continue
+		}
+		if !applyRule(ze, indirect(value), path, name, param) {
+			ze.AddField(path, ErrorValidateRuleInvalid, map[string]any{"field": path, "rule": rule})
+		}
+	}
+}
+
+// applyRule applies a single rule, returns false if the rule is unknown or not applicable to the value
+func applyRule(ze zerror.Error, value reflect.Value, path string, name string, param string) bool {
+	switch name {
+	case "min", "max", "len":
+		return applyLimit(ze, value, path, name, param)
+	case "email":
+		if value.Kind() != reflect.String {
+			return false
+		}
+		address, err := mail.ParseAddress(value.String())
+		if err != nil || address.Address != value.String() {
+			ze.AddField(path, ErrorValidateEmailInvalid, map[string]any{"field": path, "value": value.String()})
+		}
+		return true
+	case "oneof":
+		expected := strings.Fields(param)
+		actual := fmt.Sprint(value.Interface())
+		for _, option := range expected {
+			if option == actual {
+				return true
+			}
+		}
+		ze.AddField(path, ErrorValidateValueInvalid, map[string]any{"field": path, "value": actual, "expected_values": expected})
+		return true
+	}
+	return false
+}
+
+// applyLimit applies the min, max and len rules, as length for strings, slices and maps or as value for numbers
+func applyLimit(ze zerror.Error, value reflect.Value, path string, name string, param string) bool {
+	length := -1
+	switch value.Kind() {
+	case reflect.String:
+		length = utf8.RuneCountInString(value.String())
+	case reflect.Slice, reflect.Array, reflect.Map:
+		length = value.Len()
+	}
+	if length >= 0 {
+		expected, err := strconv.Atoi(param)
+		if err != nil {
+			return false
+		}
+		args := map[string]any{"field": path, "length": length, "expected_length": expected}
+		switch {
+		case name == "min" && length < expected:
+			ze.AddField(path, ErrorValidateLengthMin, args)
+		case name == "max" && length > expected:
+			ze.AddField(path, ErrorValidateLengthMax, args)
+		case name == "len" && length != expected:
+			ze.AddField(path, ErrorValidateLength, args)
+		}
+		return true
+	}
+
+	var number float64
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number = float64(value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		number = float64(value.Uint())
+	case reflect.Float32, reflect.Float64:
+		number = value.Float()
+	default:
+		return false
+	}
+	expected, err := strconv.ParseFloat(param, 64)
+	if err != nil || name == "len" {
+		return false
+	}
+	switch {
+	case name == "min" && number < expected:
+		ze.AddField(path, ErrorValidateValueMin, map[string]any{"field": path, "value": value.Interface(), "expected_min": expected})
+	case name == "max" && number > expected:
+		ze.AddField(path, ErrorValidateValueMax, map[string]any{"field": path, "value": value.Interface(), "expected_max": expected})
+	}
+	return true
+}
+
+// isEmpty returns true for missing values and zero values, used by the required rule
+func isEmpty(value reflect.Value) bool {
+	return isMissing(value) || indirect(value).IsZero()
+}
+
+// isMissing returns true for nil values and empty strings, slices and maps, the rules other than required are not applied to them
+func isMissing(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		return value.IsNil() || isMissing(value.Elem())
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return value.Len() == 0
+	}
+	return false
+}
+
+// indirect dereferences pointers and interfaces, nil values are returned unchanged
+func indirect(value reflect.Value) reflect.Value {
+	for (value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface) && !value.IsNil() {
+		value = value.Elem()
+	}
+	return value
+}
+
+// fieldName returns the name of the field in the path, the json tag name when defined
+func fieldName(structField reflect.StructField) string {
+	if name, _, _ := strings.Cut(structField.Tag.Get("json"), ","); name != "" && name != "-" {
+		return name
+	}
+	return structField.Name
+}
+
+// joinPath appends the name to the dotted path
+func joinPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
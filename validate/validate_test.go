@@ -0,0 +1,143 @@
+package validate
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/znxlc/zerror"
+)
+
+type testAddress struct {
+	Street string `json:"street" zv:"required"`
+	Zip    string `json:"zip" zv:"len=5"`
+}
+
+type testRequest struct {
+	User      string                  `json:"user" zv:"required,min=8"`
+	Email     string                  `json:"email" zv:"required,email"`
+	Age       int                     `json:"age" zv:"min=18,max=130"`
+	Role      string                  `json:"role" zv:"oneof=admin user"`
+	Nickname  string                  `json:"nickname" zv:"max=4"`
+	Addresses []testAddress           `json:"addresses" zv:"required,max=2"`
+	Contacts  map[string]*testAddress `json:"contacts"`
+	Ignored   string                  `zv:"-"`
+}
+
+func TestStruct(t *testing.T) {
+	ze := Struct(&testRequest{
+		User:      "test",
+		Email:     "not an email",
+		Age:       12,
+		Role:      "guest",
+		Addresses: []testAddress{{Street: "Main", Zip: "123"}, {Zip: "12345"}},
+		Contacts:  map[string]*testAddress{"home": {Zip: "12345"}},
+	})
+
+	assert.True(t, ze.HasErrors())
+	assert.Equal(t, []string{
+		"user: ERROR_VALIDATE_LENGTH_MIN: Field user length 4 is below 8",
+		"email: ERROR_VALIDATE_EMAIL_INVALID: Field email is not a valid email address",
+		"age: ERROR_VALIDATE_VALUE_MIN: Field age value 12 is below 18",
+		"role: ERROR_VALIDATE_VALUE_INVALID: Field role value guest is not one of [admin user]",
+		"addresses.0.zip: ERROR_VALIDATE_LENGTH_INVALID: Field addresses.0.zip length 3 is different from 5",
+		"addresses.1.street: ERROR_VALIDATE_REQUIRED: Field addresses.1.street is required",
+		"contacts.home.street: ERROR_VALIDATE_REQUIRED: Field contacts.home.street is required",
+	}, describe(ze))
+
+	lengthElement := ze.Get(0)
+	assert.Equal(t, 8, lengthElement.GetArgs()["expected_length"])
+	assert.Equal(t, http.StatusBadRequest, lengthElement.GetStatus())
+}
+
+func TestStruct_Valid(t *testing.T) {
+	ze := Struct(testRequest{
+		User:      "test_user",
+		Email:     "test@example.com",
+		Age:       30,
+		Addresses: []testAddress{{Street: "Main"}},
+	})
+	assert.False(t, ze.HasErrors())
+}
+
+func TestStruct_Invalid(t *testing.T) {
+	ze := Struct("not a struct")
+	assert.Equal(t, []string{": ERROR_VALIDATE_TARGET_INVALID: Validation target must be a struct, got string"}, describe(ze))
+
+	ze = Struct(struct {
+		Count bool `zv:"min=1,unknown"`
+	}{Count: true})
+	assert.Equal(t, []string{
+		"Count: ERROR_VALIDATE_RULE_INVALID: Field Count has an invalid validation rule min=1",
+		"Count: ERROR_VALIDATE_RULE_INVALID: Field Count has an invalid validation rule unknown",
+	}, describe(ze))
+}
+
+type testNode struct {
+	Name     string               `zv:"required"`
+	Next     *testNode            `json:"next"`
+	Children map[string]*testNode `json:"children"`
+}
+
+type testOwner struct {
+	Inner testInner  `json:"inner"`
+	Alias *testInner `json:"alias"`
+}
+
+type testInner struct {
+	Name string `json:"name" zv:"required"`
+}
+
+type testTimestamps struct {
+	Created string `json:"created" zv:"required"`
+}
+
+type testDocument struct {
+	testTimestamps
+	Title string `json:"title" zv:"required"`
+}
+
+func TestStruct_Cycle(t *testing.T) {
+	node := &testNode{}
+	node.Next = node
+	node.Children = map[string]*testNode{"self": node, "leaf": {Name: "leaf"}}
+	shared := &testNode{}
+	ze := Struct(struct {
+		Root *testNode   `json:"root"`
+		Pair []*testNode `json:"pair"`
+	}{Root: node, Pair: []*testNode{shared, shared}})
+	// the cycle is walked once, a pointer shared by different paths is validated on each path
+	assert.Equal(t, []string{
+		"root.Name: ERROR_VALIDATE_REQUIRED: Field root.Name is required",
+		"pair.0.Name: ERROR_VALIDATE_REQUIRED: Field pair.0.Name is required",
+		"pair.1.Name: ERROR_VALIDATE_REQUIRED: Field pair.1.Name is required",
+	}, describe(ze))
+
+	// a pointer to the first field has the address of the struct, it is not a cycle
+	owner := &testOwner{}
+	owner.Alias = &owner.Inner
+	ze = Struct(struct {
+		Root *testOwner `json:"root"`
+	}{Root: owner})
+	assert.Equal(t, []string{
+		"root.inner.name: ERROR_VALIDATE_REQUIRED: Field root.inner.name is required",
+		"root.alias.name: ERROR_VALIDATE_REQUIRED: Field root.alias.name is required",
+	}, describe(ze))
+}
+
+func TestStruct_EmbeddedUnexported(t *testing.T) {
+	ze := Struct(testDocument{})
+	assert.Equal(t, []string{
+		"created: ERROR_VALIDATE_REQUIRED: Field created is required",
+		"title: ERROR_VALIDATE_REQUIRED: Field title is required",
+	}, describe(ze))
+}
+
+// describe returns the field, code and message of each element
+func describe(ze zerror.Error) []string {
+	descriptions := make([]string, 0)
+	for _, errElement := range ze.GetList() {
+		descriptions = append(descriptions, string(errElement.GetField())+": "+errElement.GetCode()+": "+errElement.GetMsg())
+	}
+	return descriptions
+}
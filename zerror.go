@@ -8,6 +8,8 @@
 // Multiple errors can be added to the list using the Add function in the desired order, creating something similar to a trace list.
 //
 // Errors can be retrieved via Error(), Get() and GetList().
+//
+// NewSync creates a concurrency-safe variant that can be shared between goroutines.
 package zerror
 
 import (
@@ -33,32 +35,37 @@ func New(args ...any) Error {
 //
 // @Params
 //
-//	  args[0] [string | map[string]any | error | IElement | []IElement]
+//	  args[0] [string | map[string]any | error | IElement | []IElement | Error]
 //		    depending on type, this parameter will be interpreted as follows:
 //		    string - Error Code
-//		    error  - will set the Error Code to generic and will set Msg to error.Error()
+//		    error  - will set the Error Code to generic, will set Msg to error.Error() and keep the error as Cause
 //		    IElement - will append the IElement to the list, rest of the params will overwrite the initial element
-//		    []IElement - will append the IElement to the list, rest of the params will be ignored
+//		    []IElement - will append the IElements to the list
+//		    Error - will merge the elements of the zerror into the list
+//		    joined errors (errors.Join) - will merge the elements of every joined error, zerrors are flattened
+//		    wrapped Error (fmt.Errorf("repo: %w", ze)) - is an error, the wrapped zerror stays reachable via errors.Is/As
 //
-//	  args[1-3] [string | map[string]any | error]
+//	  args[1-3] [string | map[string]any | error | Origin]
 //			optional parameter list based on type
 //			string - IElement.Msg
 //			map[string]any - optional IElement.Args
-//			error - will set the IElement.Msg to error.Error()
+//			error - will set the IElement.Cause, a registered IElement.Msg is kept
+//			Origin - when merging a list, the elements are copied and tagged with the origin (service/repository)
+//			Field - when merging a list, the elements are copied and the field is prepended to their field path
+//			the other params are ignored when merging
 func (ze *ZError) Add(args ...any) {
   itemLen := len(args)
 
   if itemLen > 0 { // we have at least a parameter
     errorItem := args[0]
-    switch element := errorItem.(type) {
-    case []errormessage.IElement:
-      ze.Errors = append(ze.Errors, element...)
-      return
-    default: // generate a new error element
-      errElement := ze.ElementGenerator(args...)
-      ze.Errors = append(ze.Errors, errElement)
+    if errList, ok := collectElements(errorItem, ze.ElementGenerator); ok {
+      ze.merge(errList, args[1:])
       return
     }
+    // generate a new error element
+    errElement := ze.ElementGenerator(args...)
+    ze.Errors = append(ze.Errors, errElement)
+    return
   }
   // pushing rest of the args if they are IElement
   if itemLen > 1 {
@@ -93,9 +100,9 @@ func (ze *ZError) Error() string {
 // @Params
 //
 //	no param
-//	   gets first or last element as specified in zerror.ElementIndexReturned
+//	   gets first, last or most severe element as specified in zerror.ElementIndexReturned
 //	index [ int ]
-//	   gets the element specified by index from the Errors list
+//	   gets the element specified by index from the Errors list, negative indexes count from the end (-1 is the last element)
 //
 // @Returns
 //
@@ -107,13 +114,25 @@ func (ze *ZError) Get(index ...int) errormessage.IElement {
   errLen := len(ze.Errors)
   if errLen > 0 {
     if len(index) == 0 {
-      if ze.ElementIndexReturned == FlagReturnFirstErrorElement {
+      switch ze.ElementIndexReturned {
+      case FlagReturnFirstErrorElement:
         return ze.Errors[0]
+      case FlagReturnMostSevereErrorElement:
+        mostSevere := ze.Errors[0]
+        for _, errElement := range ze.Errors[1:] {
+          if errElement.GetSeverity() > mostSevere.GetSeverity() {
+            mostSevere = errElement
+          }
+        }
+        return mostSevere
       }
       return ze.Errors[errLen-1]
     }
     idx := index[0]
-    if idx >= len(ze.Errors) {
+    if idx < 0 {
+      idx += errLen
+    }
+    if idx < 0 || idx >= errLen {
       return nil
     }
     return ze.Errors[idx]
@@ -136,15 +155,107 @@ func (ze *ZError) Has(errCode string) bool {
   return false
 }
 
+// HasMatch will return true if the Errors list contains a code matching the pattern, e.g. ERROR_USER_* (see errormessage.MatchCode)
+func (ze *ZError) HasMatch(pattern string) bool {
+  return ze.CountMatch(pattern) > 0
+}
+
+// CountMatch returns the number of elements with a code matching the pattern (see errormessage.MatchCode)
+func (ze *ZError) CountMatch(pattern string) int {
+  count := 0
+  for _, errElement := range ze.Errors {
+    if errormessage.MatchCode(pattern, errElement.GetCode()) {
+      count++
+    }
+  }
+  return count
+}
+
+// HasPrefix will return true if the Errors list contains a code of the family, e.g. ERROR_DB (see errormessage.HasCodePrefix)
+func (ze *ZError) HasPrefix(family string) bool {
+  return ze.CountPrefix(family) > 0
+}
+
+// CountPrefix returns the number of elements with a code of the family (see errormessage.HasCodePrefix)
+func (ze *ZError) CountPrefix(family string) int {
+  count := 0
+  for _, errElement := range ze.Errors {
+    if errormessage.HasCodePrefix(errElement.GetCode(), family) {
+      count++
+    }
+  }
+  return count
+}
+
 // HasErrors will return true if the Errors list contains elements
-func (ze *ZError) HasErrors() bool {
-  return len(ze.Errors) > 0
+//
+// @Params
+//
+//	no param
+//	   any element is considered
+//	minSeverity [ errormessage.Severity ]
+//	   only the elements with at least this severity are considered, e.g. errormessage.SeverityError ignores warnings
+func (ze *ZError) HasErrors(minSeverity ...errormessage.Severity) bool {
+  if len(minSeverity) == 0 {
+    return len(ze.Errors) > 0
+  }
+  for _, errElement := range ze.Errors {
+    if errElement.GetSeverity() >= minSeverity[0] {
+      return true
+    }
+  }
+  return false
+}
+
+// Is reports whether the Errors list contains an element matching target, used by errors.Is
+//
+// @Params
+//
+//	target [ Code | errormessage.IElement ]
+//	   matches if an element in the list has the same error code
+func (ze *ZError) Is(target error) bool {
+  switch t := target.(type) {
+  case Code:
+    return ze.Has(string(t))
+  case errormessage.IElement:
+    return ze.Has(t.GetCode())
+  }
+  return false
+}
+
+// SetElementGenerator will set the generator used to create new elements, e.g. registry.New to bind the zerror to a specific errormessage.Registry
+func (ze *ZError) SetElementGenerator(generator errormessage.ErrorElementGenerator) {
+  if generator != nil {
+    ze.ElementGenerator = generator
+  }
+}
+
+// Unwrap returns the Errors list as a slice of errors so errors.Is and errors.As can inspect every element
+func (ze *ZError) Unwrap() []error {
+  errList := make([]error, 0, len(ze.Errors))
+  for _, errElement := range ze.Errors {
+    errList = append(errList, errElement)
+  }
+  return errList
+}
+
+// Localize returns a copy of the zerror with the element messages translated in the requested locale (see errormessage.Registry.Translate)
+func (ze *ZError) Localize(locale string) Error {
+  localized := &ZError{
+    ElementIndexReturned: ze.ElementIndexReturned,
+    ElementGenerator:     ze.ElementGenerator,
+    Errors:               make([]errormessage.IElement, 0, len(ze.Errors)),
+  }
+  for _, errElement := range ze.Errors {
+    localized.Errors = append(localized.Errors, errElement.Localize(locale))
+  }
+  return localized
 }
 
 // SetDefaultElementIndexReturned will set the default element returned when using Get() or Error()
 func (ze *ZError) SetDefaultElementIndexReturned(flag string) {
   switch flag {
-  case FlagReturnFirstErrorElement, FlagReturnLastErrorElement:
+  case FlagReturnFirstErrorElement, FlagReturnLastErrorElement, FlagReturnMostSevereErrorElement:
     ze.ElementIndexReturned = flag
   }
 }
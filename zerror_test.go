@@ -1,8 +1,14 @@
 package zerror
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/znxlc/zerror/errormessage"
+	"log/slog"
+	"sync"
 	"testing"
 )
 
@@ -54,3 +60,550 @@ func TestZError_Get(t *testing.T) {
 	assert.Equal(t, "ERROR_3", zeTest.Get().GetCode())
 	assert.Equal(t, "ERROR_2", zeTest.Get(1).GetCode())
 }
+
+func TestZError_Trace(t *testing.T) {
+	ze := New(errormessage.ErrorInternal)
+	ze.Add("ERROR_2")
+
+	for _, errElement := range ze.GetList() {
+		trace := errElement.GetTrace()
+		assert.NotEmpty(t, trace)
+		assert.Equal(t, "github.com/znxlc/zerror.TestZError_Trace", trace[0].Function)
+		assert.Contains(t, trace[0].File, "zerror_test.go")
+	}
+
+	errormessage.TraceMarshal = true
+	defer func() { errormessage.TraceMarshal = false }()
+	data, err := json.Marshal(ze.Get())
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"trace":[{"function":"github.com/znxlc/zerror.TestZError_Trace"`)
+}
+
+func TestZError_Is(t *testing.T) {
+	var err error = New("ERROR_USER_INVALID")
+	err.(*ZError).Add("ERROR_USER_LENGTH")
+
+	assert.True(t, errors.Is(err, Code("ERROR_USER_LENGTH")))
+	assert.True(t, errors.Is(err, errormessage.New("ERROR_USER_INVALID")))
+	assert.False(t, errors.Is(err, Code("ERROR_USER_MISSING")))
+
+	var errElement errormessage.IElement
+	assert.True(t, errors.As(err, &errElement))
+	assert.Equal(t, "ERROR_USER_INVALID", errElement.GetCode())
+	assert.Len(t, err.(*ZError).Unwrap(), 2)
+}
+
+type testCauseError struct {
+	code int
+}
+
+func (e *testCauseError) Error() string {
+	return "test cause"
+}
+
+func TestZError_Cause(t *testing.T) {
+	errNotFound := errors.New("not found")
+	ze := New(errNotFound)
+	ze.Add(errormessage.ErrorInternal, &testCauseError{code: 42})
+
+	assert.True(t, errors.Is(ze, errNotFound))
+	var causeErr *testCauseError
+	assert.True(t, errors.As(ze, &causeErr))
+	assert.Equal(t, 42, causeErr.code)
+
+	// registered Msg is kept, the cause is serialized separately
+	errElement := ze.Get(1)
+	assert.Equal(t, "An internal error has occurred", errElement.GetMsg())
+	data, err := json.Marshal(errElement)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"args":null,"code":"ERROR_INTERNAL","msg":"An internal error has occurred","cause":"test cause","status":500}`, string(data))
+}
+
+func TestSyncZError_Concurrent(t *testing.T) {
+	ze := NewSync()
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				ze.Add(errormessage.ErrorInternal)
+				_ = ze.Get()
+				_ = ze.Has(errormessage.ErrorInternal)
+				_ = ze.GetList()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1000, len(ze.GetList()))
+	assert.True(t, errors.Is(ze, Code(errormessage.ErrorInternal)))
+	ze.Clear()
+	assert.False(t, ze.HasErrors())
+}
+
+func TestZError_RegisterConcurrent(t *testing.T) {
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			errormessage.RegisterErrors(
+				errormessage.Message{Code: "ERROR_PLUGIN_FIRST", Msg: "first plugin error"},
+				errormessage.Message{Code: "ERROR_PLUGIN_SECOND", Msg: "second plugin error"},
+			)
+		}()
+		go func() {
+			defer wg.Done()
+			ze := New("ERROR_PLUGIN_SECOND")
+			// the batch is published at once, when the second message is visible the first must be too
+			if ze.Get().GetMsg() == "second plugin error" {
+				assert.Equal(t, "first plugin error", errormessage.GetRegisteredElement("ERROR_PLUGIN_FIRST").GetMsg())
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, "second plugin error", New("ERROR_PLUGIN_SECOND").Get().GetMsg())
+}
+
+func TestZError_Registry(t *testing.T) {
+	billing := errormessage.NewRegistry(errormessage.Message{Code: "ERROR_NOT_FOUND", Msg: "invoice not found"})
+	users := errormessage.NewRegistry(errormessage.Message{Code: "ERROR_NOT_FOUND", Msg: "user not found"})
+
+	zeBilling := New()
+	zeBilling.SetElementGenerator(billing.New)
+	zeBilling.Add("ERROR_NOT_FOUND")
+	zeUsers := New()
+	zeUsers.SetElementGenerator(users.New)
+	zeUsers.Add("ERROR_NOT_FOUND")
+
+	assert.Equal(t, "invoice not found", zeBilling.Get().GetMsg())
+	assert.Equal(t, "user not found", zeUsers.Get().GetMsg())
+	// predefined messages are available in every registry, the default registry is not affected
+	assert.Equal(t, "An internal error has occurred", users.New(errormessage.ErrorInternal).GetMsg())
+	_, found := errormessage.DefaultRegistry.Lookup("ERROR_NOT_FOUND")
+	assert.False(t, found)
+}
+
+func TestZError_MessageTemplate(t *testing.T) {
+	errormessage.RegisterErrors(errormessage.Message{
+		Code: "ERROR_USER_LENGTH",
+		Msg:  "User length {user_length} is below {expected_length}, user {user}",
+	})
+	ze := New("ERROR_USER_LENGTH", map[string]any{"user_length": 3, "expected_length": 8})
+
+	assert.Equal(t, "User length 3 is below 8, user {user}", ze.Get().GetMsg())
+	assert.Equal(t, "User length 3 is below 8, user {user}", ze.Get().Error())
+	assert.Equal(t, "User length {user_length} is below {expected_length}, user {user}", ze.Get().GetMsgTemplate())
+	assert.Equal(t, []string{"user_length", "expected_length", "user"}, errormessage.TemplatePlaceholders(ze.Get().GetMsgTemplate()))
+	assert.Equal(t, "no {placeholders here} {}", errormessage.RenderMessage("no {placeholders here} {}", nil))
+}
+
+func TestZError_Localize(t *testing.T) {
+	registry := errormessage.NewRegistry(errormessage.Message{
+		Code: "ERROR_USER_LENGTH",
+		Msg:  "User length {user_length} is below {expected_length}",
+	})
+	registry.Register(`
+locale: pt
+messages:
+  ERROR_USER_LENGTH:
+    msg: O comprimento {user_length} é menor que {expected_length}
+`)
+	registry.Register(`{"locale": "pt-BR", "messages": {"ERROR_INTERNAL": {"msg": "Ocorreu um erro interno"}}}`)
+
+	ze := New()
+	ze.SetElementGenerator(registry.New)
+	ze.Add("ERROR_USER_LENGTH", map[string]any{"user_length": 3, "expected_length": 8})
+	ze.Add(errormessage.ErrorInternal)
+	ze.Add(errormessage.ErrorInternal, "custom message")
+
+	localized := ze.Localize("pt_BR")
+	assert.Equal(t, "O comprimento 3 é menor que 8", localized.Get(0).GetMsg())
+	assert.Equal(t, "Ocorreu um erro interno", localized.Get(1).GetMsg())
+	assert.Equal(t, "custom message", localized.Get(2).GetMsg())
+	// the original is unchanged, unknown locales fall back to the default message
+	assert.Equal(t, "User length 3 is below 8", ze.Get(0).GetMsg())
+	assert.Equal(t, "An internal error has occurred", ze.Localize("de").Get(1).GetMsg())
+	assert.Equal(t, []string{"pt-br", "pt"}, errormessage.LocaleFallback("pt_BR"))
+}
+
+func TestZError_Slog(t *testing.T) {
+	traceDepth := errormessage.TraceDepth
+	errormessage.TraceDepth = 0
+	defer func() { errormessage.TraceDepth = traceDepth }()
+	ze := New("ERROR_USER_LENGTH", "User length is invalid", map[string]any{"user_length": 3, "expected_length": 8})
+	ze.Add(errormessage.ErrorInternal, errors.New("db down"))
+
+	buffer := bytes.Buffer{}
+	logger := slog.New(NewSlogHandler(slog.NewTextHandler(&buffer, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return attr
+		},
+	})))
+	logger.Info("request failed", "err", fmt.Errorf("process: %w", ze))
+
+	assert.Equal(t, `level=INFO msg="request failed" err.error="process: ERROR_USER_LENGTH" `+
+		`err.0.code=ERROR_USER_LENGTH err.0.msg="User length is invalid" err.0.args.expected_length=8 err.0.args.user_length=3 `+
+		`err.1.code=ERROR_INTERNAL err.1.msg="An internal error has occurred" err.1.cause="db down"`+"\n", buffer.String())
+}
+
+func TestZError_Format(t *testing.T) {
+	traceDepth := errormessage.TraceDepth
+	errormessage.TraceDepth = 0
+	defer func() { errormessage.TraceDepth = traceDepth }()
+	ze := New("ERROR_USER_LENGTH", "User length is invalid", map[string]any{"user_length": 3, "expected_length": 8})
+	ze.Add(errormessage.ErrorInternal, errors.New("db down"), errormessage.TraceElement{Function: "main.run", File: "main.go", Line: 12})
+
+	assert.Equal(t, "ERROR_USER_LENGTH", fmt.Sprintf("%v", ze))
+	assert.Equal(t, "ERROR_USER_LENGTH", fmt.Sprintf("%s", ze))
+	assert.Equal(t, `"ERROR_USER_LENGTH"`, fmt.Sprintf("%q", ze))
+	assert.Equal(t, "User length is invalid", fmt.Sprintf("%v", ze.Get()))
+	assert.Equal(t, `errors: 2
+[0]
+  code: ERROR_USER_LENGTH
+  msg:  User length is invalid
+  args:
+    expected_length: 8
+    user_length: 3
+[1]
+  code: ERROR_INTERNAL
+  msg:  An internal error has occurred
+  cause: db down
+  trace:
+    main.run
+      main.go:12
+`, fmt.Sprintf("%+v", ze))
+}
+
+func TestZError_JSON(t *testing.T) {
+	errormessage.TraceMarshal = true
+	defer func() { errormessage.TraceMarshal = false }()
+	ze := New("ERROR_USER_LENGTH", "User length {user_length} is invalid", map[string]any{"user_length": 3})
+	ze.Add(errormessage.ErrorInternal, errors.New("db down"))
+
+	data, err := json.Marshal(ze)
+	assert.NoError(t, err)
+
+	decoded := New()
+	assert.NoError(t, json.Unmarshal(data, decoded))
+	assert.Equal(t, len(ze.GetList()), len(decoded.GetList()))
+	for idx, errElement := range ze.GetList() {
+		decodedElement := decoded.Get(idx)
+		assert.Equal(t, errElement.GetCode(), decodedElement.GetCode())
+		assert.Equal(t, errElement.GetMsg(), decodedElement.GetMsg())
+		assert.Equal(t, errElement.GetMsgTemplate(), decodedElement.GetMsgTemplate())
+		assert.Equal(t, errElement.GetStatus(), decodedElement.GetStatus())
+		assert.Equal(t, errElement.GetTrace(), decodedElement.GetTrace())
+	}
+	assert.Equal(t, float64(3), decoded.Get(0).GetArgs()["user_length"])
+	assert.Equal(t, "db down", decoded.Get(1).Unwrap().Error())
+	assert.Equal(t, "User length 3 is invalid", decoded.Get(0).GetMsg())
+	assert.Contains(t, string(data), `"rendered":"User length 3 is invalid"`)
+
+	dataDecoded, err := json.Marshal(decoded)
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(data), string(dataDecoded))
+
+	registry := errormessage.NewRegistry(errormessage.Message{Code: "ERROR_USER_LENGTH", Msg: "User length {user_length} is below {expected_length}"})
+	registry.Register(errormessage.Message{Code: "ERROR_USER_LENGTH", Locale: "pt", Msg: "O comprimento {user_length} é menor que {expected_length}"})
+	localizable := New()
+	localizable.SetElementGenerator(registry.New)
+	localizable.Add("ERROR_USER_LENGTH", map[string]any{"user_length": 3, "expected_length": 8})
+	data, err = json.Marshal(localizable)
+	assert.NoError(t, err)
+	decodedLocalizable := New()
+	decodedLocalizable.SetElementGenerator(registry.New)
+	assert.NoError(t, json.Unmarshal(data, decodedLocalizable))
+	assert.Equal(t, "O comprimento 3 é menor que 8", decodedLocalizable.Localize("pt").Get().GetMsg())
+}
+
+func TestZError_Severity(t *testing.T) {
+	ze := New("WARNING_USER_EMAIL_UNVERIFIED", errormessage.SeverityWarning)
+	assert.True(t, ze.HasErrors())
+	assert.False(t, ze.HasErrors(errormessage.SeverityError))
+
+	ze.Add("ERROR_USER_INVALID")
+	ze.Add(errormessage.ErrorPanic)
+	ze.Add("ERROR_CRITICAL", errormessage.SeverityCritical)
+	assert.True(t, ze.HasErrors(errormessage.SeverityError))
+	assert.Equal(t, errormessage.SeverityError, ze.Get(1).GetSeverity())
+
+	ze.SetDefaultElementIndexReturned(FlagReturnMostSevereErrorElement)
+	assert.Equal(t, errormessage.ErrorPanic, ze.Get().GetCode())
+	assert.Equal(t, errormessage.ErrorPanic, ze.Error())
+}
+
+func TestZError_Field(t *testing.T) {
+	ze := New()
+	ze.AddField("user.email", "ERROR_USER_EMAIL_INVALID", "Email is invalid")
+	ze.AddField("/user/email", "ERROR_USER_EMAIL_DOMAIN", "Email domain is not allowed")
+	ze.AddField("user.name", "ERROR_USER_NAME_MISSING", "Name is missing")
+	ze.Add("ERROR_REQUEST_VALIDATION", "Request validation error")
+
+	assert.Equal(t, Field("user.email"), ze.Get(1).GetField())
+	assert.Equal(t, "/user/email", ze.Get(1).GetField().Pointer())
+	assert.Equal(t, "a/b.c~d", Field("/a~1b/c~0d").Dotted())
+
+	fieldErrors := ze.GroupByField()
+	assert.Len(t, fieldErrors, 2)
+	assert.Len(t, fieldErrors["user.email"], 2)
+
+	data, err := json.Marshal(fieldErrors)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"user.email": [
+			{"args": null, "code": "ERROR_USER_EMAIL_INVALID", "msg": "Email is invalid", "field": "user.email"},
+			{"args": null, "code": "ERROR_USER_EMAIL_DOMAIN", "msg": "Email domain is not allowed", "field": "user.email"}
+		],
+		"user.name": [
+			{"args": null, "code": "ERROR_USER_NAME_MISSING", "msg": "Name is missing", "field": "user.name"}
+		]
+	}`, string(data))
+
+	inner := New("ERROR_USER_INVALID")
+	inner.AddField("street", "ERROR_USER_NAME_MISSING")
+	merged := New()
+	merged.AddField("/user/address", inner)
+	merged.AddField("user.tags", inner.GetList())
+	assert.Equal(t, []Field{"user.address", "user.address.street", "user.tags", "user.tags.street"}, []Field{
+		merged.Get(0).GetField(), merged.Get(1).GetField(), merged.Get(2).GetField(), merged.Get(3).GetField(),
+	})
+	assert.Equal(t, Field("street"), inner.Get(1).GetField())
+}
+
+func TestZError_CodeMatch(t *testing.T) {
+	ze := New("ERROR_USER_LENGTH")
+	ze.Add("ERROR_USER_NAME_INVALID")
+	ze.Add("ERROR_DB_CONNECTION_FAILED")
+	ze.Add("ERROR_DB")
+	ze.Add("ERROR_DBX_TIMEOUT")
+
+	assert.True(t, ze.HasMatch("ERROR_USER_*"))
+	assert.Equal(t, 2, ze.CountMatch("ERROR_USER_*"))
+	assert.Equal(t, 1, ze.CountMatch("ERROR_*_*_FAILED"))
+	assert.Equal(t, 2, ze.CountMatch("ERROR_DB*_*"))
+	assert.False(t, ze.HasMatch("ERROR_ORDER_*"))
+	assert.Equal(t, 2, ze.CountPrefix("ERROR_DB"))
+	assert.True(t, ze.HasPrefix("ERROR_USER_NAME"))
+
+	parsed := errormessage.ParseCode("ERROR_DB_CONNECTION_FAILED")
+	assert.Equal(t, "ERROR", parsed.Prefix)
+	assert.Equal(t, "DB", parsed.Entity)
+	assert.Equal(t, "CONNECTION", parsed.Attribute)
+	assert.Equal(t, "FAILED", parsed.Verb)
+	assert.Equal(t, "USER", Code("ERROR_USER_INVALID").Parse().Entity)
+	assert.Equal(t, "", Code("ERROR_USER_INVALID").Parse().Attribute)
+}
+
+func TestZError_Query(t *testing.T) {
+	ze := New("ERROR_USER_LENGTH")
+	ze.Add("ERROR_DB_CONNECTION_FAILED")
+	ze.Add("ERROR_USER_LENGTH", errormessage.SeverityWarning)
+	ze.Add("ERROR_USER_NAME_INVALID")
+
+	assert.Equal(t, "ERROR_USER_NAME_INVALID", ze.Get(-1).GetCode())
+	assert.Equal(t, "ERROR_USER_LENGTH", ze.Get(-4).GetCode())
+	assert.Nil(t, ze.Get(-5))
+	assert.Nil(t, ze.Get(4))
+
+	assert.Len(t, ze.Find("ERROR_USER_LENGTH"), 2)
+	assert.Len(t, ze.Filter(func(el errormessage.IElement) bool {
+		return el.GetSeverity() == errormessage.SeverityError
+	}), 3)
+	assert.Equal(t, []string{"ERROR_USER_LENGTH", "ERROR_DB_CONNECTION_FAILED", "ERROR_USER_NAME_INVALID"}, ze.Codes())
+	assert.Equal(t, map[string]int{"ERROR_USER_LENGTH": 2, "ERROR_DB_CONNECTION_FAILED": 1, "ERROR_USER_NAME_INVALID": 1}, ze.CountByCode())
+
+	groups := ze.GroupBy(func(el errormessage.IElement) string {
+		return el.GetSeverity().String()
+	})
+	assert.Len(t, groups["error"], 3)
+	assert.Len(t, groups["warning"], 1)
+
+	assert.Equal(t, 2, ze.Remove("ERROR_USER_LENGTH"))
+	assert.Equal(t, 0, ze.Remove("ERROR_USER_LENGTH"))
+	assert.Equal(t, []string{"ERROR_DB_CONNECTION_FAILED", "ERROR_USER_NAME_INVALID"}, ze.Codes())
+
+	sze := NewSync("ERROR_USER_LENGTH")
+	sze.Add("ERROR_DB_CONNECTION_FAILED")
+	assert.Equal(t, "ERROR_DB_CONNECTION_FAILED", sze.Get(-1).GetCode())
+	assert.Equal(t, 1, sze.Remove("ERROR_DB_CONNECTION_FAILED"))
+	assert.Len(t, sze.GetList(), 1)
+}
+
+func TestZError_Merge(t *testing.T) {
+	repository := New("ERROR_DB_CONNECTION_FAILED")
+	service := New("ERROR_USER_LENGTH")
+	service.Add(repository, Origin("repository"))
+
+	ze := New()
+	ze.Add(service, Origin("service"))
+	assert.Len(t, ze.GetList(), 2)
+	assert.Equal(t, Origin("service"), ze.Get(0).GetOrigin())
+	assert.Equal(t, Origin("service/repository"), ze.Get(1).GetOrigin())
+	assert.Equal(t, Origin("repository"), service.Get(1).GetOrigin())
+	assert.Equal(t, Origin(""), repository.Get().GetOrigin())
+
+	joined := errors.Join(repository, errors.New("plain error"), errors.Join(New("ERROR_USER_NAME_INVALID")))
+	ze = New()
+	ze.Add(joined)
+	assert.Equal(t, []string{"ERROR_DB_CONNECTION_FAILED", errormessage.ErrorGeneric, "ERROR_USER_NAME_INVALID"}, ze.Codes())
+	assert.Equal(t, "plain error", ze.Get(1).GetMsg())
+
+	// multiple %w keep their message, a wrapped zerror is merged
+	wrapped := fmt.Errorf("loading user 5: %w, %w", errors.New("a"), errors.New("b"))
+	ze = New(wrapped)
+	assert.Equal(t, []string{errormessage.ErrorGeneric}, ze.Codes())
+	assert.Equal(t, "loading user 5: a, b", ze.Get().GetMsg())
+	assert.Equal(t, wrapped, ze.Get().Unwrap())
+	repoErr := fmt.Errorf("loading user 42: %w", service)
+	ze = New(repoErr)
+	assert.Equal(t, []string{errormessage.ErrorGeneric}, ze.Codes())
+	assert.Equal(t, "loading user 42: ERROR_USER_LENGTH", ze.Get().GetMsg())
+	assert.Equal(t, repoErr, ze.Get().Unwrap())
+	assert.True(t, errors.Is(ze, Code("ERROR_DB_CONNECTION_FAILED")))
+	ze = New(errors.Join(repoErr, errors.New("plain error")))
+	assert.Equal(t, []string{errormessage.ErrorGeneric}, ze.Codes())
+	assert.Equal(t, "loading user 42: ERROR_USER_LENGTH", ze.Get(0).GetMsg())
+
+	sze := NewSync("ERROR_USER_LENGTH")
+	sze.Add(sze, Origin("self"))
+	assert.Equal(t, 2, sze.CountByCode()["ERROR_USER_LENGTH"])
+	assert.Equal(t, Origin("self"), sze.Get(-1).GetOrigin())
+}
+
+func TestSyncZError_AddChildren(t *testing.T) {
+	sze := NewSync("ERROR_USER_LENGTH")
+	sze.Add("ERROR_REQUEST_VALIDATION", "parent", sze)
+	assert.Equal(t, []string{"ERROR_USER_LENGTH"}, NewSync(sze.Get(-1).GetChildren()).Codes())
+
+	// two zerrors adding each other as children concurrently
+	first, second := NewSync("ERROR_USER_LENGTH"), NewSync("ERROR_DB_CONNECTION_FAILED")
+	wg := sync.WaitGroup{}
+	for idx := 0; idx < 100; idx++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			first.Add("ERROR_REQUEST_VALIDATION", second)
+		}()
+		go func() {
+			defer wg.Done()
+			second.Add("ERROR_REQUEST_VALIDATION", first)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 101, len(first.GetList()))
+	assert.Equal(t, 101, len(second.GetList()))
+}
+
+func TestSyncZError_AddFieldChildren(t *testing.T) {
+	sze := NewSync("ERROR_USER_LENGTH")
+	sze.AddField("user", sze)
+	sze.AddField("user", "ERROR_REQUEST_VALIDATION", sze)
+	sze.AddField("user")
+	assert.Len(t, sze.GetList(), 4)
+	assert.Equal(t, []string{"ERROR_USER_LENGTH", "ERROR_REQUEST_VALIDATION", errormessage.ErrorGeneric}, sze.Codes())
+	assert.Equal(t, Field("user"), sze.Get(1).GetField())
+	assert.Len(t, sze.Get(2).GetChildren(), 2)
+	assert.Equal(t, Field("user"), sze.Get(-1).GetField())
+
+	first, second := NewSync("ERROR_USER_LENGTH"), NewSync("ERROR_DB_CONNECTION_FAILED")
+	wg := sync.WaitGroup{}
+	for idx := 0; idx < 100; idx++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			first.AddField("second", "ERROR_REQUEST_VALIDATION", second)
+		}()
+		go func() {
+			defer wg.Done()
+			second.AddField("first", "ERROR_REQUEST_VALIDATION", first)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 101, len(first.GetList()))
+	assert.Equal(t, 101, len(second.GetList()))
+}
+
+func TestZError_Tree(t *testing.T) {
+	fieldErrors := New()
+	fieldErrors.AddField("user", "ERROR_USER_LENGTH", "User too short")
+	fieldErrors.AddField("email", "ERROR_USER_NAME_INVALID", "Email invalid")
+
+	ze := New("ERROR_REQUEST_VALIDATION", "Request validation error", fieldErrors)
+	ze.Get().GetChildren()[0].AddChild(errormessage.New("ERROR_DB_CONNECTION_FAILED", "Lookup failed"))
+
+	assert.Len(t, ze.GetList(), 1)
+	assert.Len(t, ze.Get().GetChildren(), 2)
+	assert.Equal(t, "ERROR_REQUEST_VALIDATION: Request validation error\n"+
+		"  ERROR_USER_LENGTH: User too short\n"+
+		"    ERROR_DB_CONNECTION_FAILED: Lookup failed\n"+
+		"  ERROR_USER_NAME_INVALID: Email invalid\n", ze.Tree())
+
+	depths := map[string]int{}
+	ze.Walk(func(el errormessage.IElement, depth int) bool {
+		depths[el.GetCode()] = depth
+		return el.GetCode() != "ERROR_USER_LENGTH"
+	})
+	assert.Equal(t, map[string]int{"ERROR_REQUEST_VALIDATION": 0, "ERROR_USER_LENGTH": 1, "ERROR_USER_NAME_INVALID": 1}, depths)
+
+	assert.True(t, errors.Is(ze, Code("ERROR_DB_CONNECTION_FAILED")))
+	assert.False(t, errors.Is(ze, Code("ERROR_ORDER_INVALID")))
+	assert.Contains(t, fmt.Sprintf("%+v", ze), "  children:\n    [0]\n      code: ERROR_USER_LENGTH\n")
+
+	data, err := json.Marshal(ze)
+	assert.NoError(t, err)
+	decoded := New()
+	assert.NoError(t, json.Unmarshal(data, decoded))
+	assert.Equal(t, ze.Tree(), decoded.Tree())
+	assert.Equal(t, Field("user"), decoded.Get().GetChildren()[0].GetField())
+}
+
+func TestZError_Recover(t *testing.T) {
+	process := func(previous error, value any) (err error) {
+		err = previous
+		defer Recover(&err)
+		panic(value)
+	}
+
+	err := process(nil, "boom")
+	ze := Error(nil)
+	assert.True(t, errors.As(err, &ze))
+	assert.Equal(t, errormessage.ErrorPanic, ze.Get().GetCode())
+	assert.Equal(t, errormessage.SeverityCritical, ze.Get().GetSeverity())
+	assert.Equal(t, "boom", ze.Get().GetArgs()["panic"])
+	assert.Equal(t, "string", ze.Get().GetArgs()["type"])
+	assert.Contains(t, ze.Get().GetArgs()["stack"], "runtime/debug.Stack")
+
+	cause := errors.New("nil map")
+	err = process(New("ERROR_USER_LENGTH"), cause)
+	assert.True(t, errors.Is(err, cause))
+	assert.True(t, errors.Is(err, Code("ERROR_USER_LENGTH")))
+	assert.True(t, errors.As(err, &ze))
+	assert.Equal(t, []string{errormessage.ErrorPanic, "ERROR_USER_LENGTH"}, ze.Codes())
+	assert.Equal(t, "*errors.errorString", ze.Get().GetArgs()["type"])
+
+	assert.NoError(t, func() (err error) {
+		defer Recover(&err)
+		return nil
+	}())
+
+	assert.PanicsWithValue(t, "boom", func() {
+		defer Recover(nil)
+		panic("boom")
+	})
+
+	handled := Error(nil)
+	func() {
+		defer RecoverWith(nil, func(ze Error) {
+			handled = ze
+		})
+		panic(42)
+	}()
+	assert.True(t, handled.Has(errormessage.ErrorPanic))
+	assert.Equal(t, 42, handled.Get().GetArgs()["panic"])
+}